@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"sort"
+)
+
+// AnchorRedirects returns a map from the anchor of each definition when --use-tags is oldUseTags to its current
+// anchor.  Definitions with unchanged anchors are omitted.  Anchors built from tags omit the group, so when
+// several definitions share an old anchor it redirects to the one that is not replaced by another definition.
+func (d *Definitions) AnchorRedirects(oldUseTags bool) map[string]string {
+	keys := []string{}
+	for k := range d.GetAllDefinitions() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	redirects := map[string]string{}
+	targets := map[string]*Definition{}
+	for _, k := range keys {
+		definition := d.ByGroupVersionKind[k]
+		from, to := definition.anchor(oldUseTags), definition.Anchor()
+		if from == to {
+			continue
+		}
+		if existing, found := targets[from]; found && (existing.ReplacedBy == nil || definition.ReplacedBy != nil) {
+			continue
+		}
+		redirects[from] = to
+		targets[from] = definition
+	}
+	return redirects
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnchorRedirects(t *testing.T) {
+	saved := DefinitionReplacements
+	DefinitionReplacements = map[string]string{"extensions.v1beta1.Deployment": "apps.v1beta1.Deployment"}
+	t.Cleanup(func() { DefinitionReplacements = saved })
+
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":                   object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Deployment":       object(schema{"kind": str()}),
+		"io.k8s.api.extensions.v1beta1.Deployment": object(schema{"kind": str()}),
+	})
+
+	setFlag(t, "use-tags", "false")
+	expected := map[string]string{
+		"pod-v1":             "pod-v1-core",
+		"deployment-v1beta1": "deployment-v1beta1-apps",
+	}
+	if got := d.AnchorRedirects(true); !reflect.DeepEqual(got, expected) {
+		t.Errorf("AnchorRedirects(true) = %v, want %v", got, expected)
+	}
+
+	setFlag(t, "use-tags", "true")
+	expected = map[string]string{
+		"pod-v1-core":                   "pod-v1",
+		"deployment-v1beta1-apps":       "deployment-v1beta1",
+		"deployment-v1beta1-extensions": "deployment-v1beta1",
+	}
+	if got := d.AnchorRedirects(false); !reflect.DeepEqual(got, expected) {
+		t.Errorf("AnchorRedirects(false) = %v, want %v", got, expected)
+	}
+
+	if got := d.AnchorRedirects(true); len(got) != 0 {
+		t.Errorf("AnchorRedirects() with unchanged anchors = %v, want none", got)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+var AutoExamples = flag.Bool("auto-examples", false, "If true and operations are not built, generate samples for definitions without a sample in the config.")
+
+// maxAutoExampleDepth bounds the depth of nested definitions written by AutoExample
+const maxAutoExampleDepth = 5
+
+// AutoExample is an ExampleProvider generating a minimal yaml sample from the required fields of a definition
+// for definitions without a sample in the config
+type AutoExample struct {
+	EmptyExample
+}
+
+var _ ExampleProvider = &AutoExample{}
+
+// AutoExampleProviders replace the EmptyExampleProviders when --auto-examples is set
+var AutoExampleProviders = []ExampleProvider{
+	AutoExampleProvider(),
+}
+
+// AutoExampleProvider returns an ExampleProvider generating samples from the definition schemas
+func AutoExampleProvider() ExampleProvider {
+	return &AutoExample{}
+}
+
+func (ae AutoExample) GetSample(d *Definition) string {
+	if len(d.Sample.Sample) > 0 {
+		return d.Sample.Sample
+	}
+	apiVersion := d.Version.String()
+	if g := d.GroupFullName(); len(g) > 0 {
+		apiVersion = g + "/" + apiVersion
+	}
+	lines := []string{
+		"apiVersion: " + apiVersion,
+		"kind: " + d.Name,
+	}
+	for _, line := range autoExampleLines(d, maxAutoExampleDepth) {
+		if !strings.HasPrefix(line, "apiVersion:") && !strings.HasPrefix(line, "kind:") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// autoExampleLines returns the yaml lines for the required fields of d
+func autoExampleLines(d *Definition, depth int) []string {
+	lines := []string{}
+	for _, field := range d.Fields {
+		if !field.Required {
+			continue
+		}
+		if field.Default != nil {
+			if b, err := json.Marshal(field.Default); err == nil {
+				lines = append(lines, fmt.Sprintf("%s: %s", field.Name, b))
+				continue
+			}
+		}
+		nested := []string{}
+		if field.Definition != nil && depth > 0 {
+			nested = autoExampleLines(field.Definition, depth-1)
+		}
+		switch {
+		case field.Kind() == FieldKindArray && len(nested) > 0:
+			lines = append(lines, field.Name+":")
+			for i, line := range nested {
+				prefix := "  "
+				if i == 0 {
+					prefix = "- "
+				}
+				lines = append(lines, prefix+line)
+			}
+		case field.Kind() == FieldKindArray:
+			lines = append(lines, fmt.Sprintf("%s: [%s]", field.Name, autoExamplePlaceholder(strings.TrimSuffix(field.Type, " array"))))
+		case len(nested) > 0:
+			lines = append(lines, field.Name+":")
+			for _, line := range nested {
+				lines = append(lines, "  "+line)
+			}
+		case field.Definition != nil || field.Kind() == FieldKindMap:
+			lines = append(lines, field.Name+": {}")
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s", field.Name, autoExamplePlaceholder(field.Type)))
+		}
+	}
+	return lines
+}
+
+// autoExamplePlaceholder returns a placeholder value for a primitive type
+func autoExamplePlaceholder(t string) string {
+	switch t {
+	case "integer", "number":
+		return "0"
+	case "boolean":
+		return "false"
+	case "object":
+		return "{}"
+	}
+	return fmt.Sprintf("%q", t)
+}
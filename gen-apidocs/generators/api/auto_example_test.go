@@ -0,0 +1,132 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+)
+
+func newAutoExampleDefinitions(t *testing.T) Definitions {
+	return newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": schema{
+			"type":     "object",
+			"required": []string{"spec"},
+			"properties": schema{
+				"kind":   str(),
+				"spec":   ref("io.k8s.api.apps.v1.DeploymentSpec"),
+				"status": str(),
+			},
+		},
+		"io.k8s.api.apps.v1.DeploymentSpec": schema{
+			"type":     "object",
+			"required": []string{"containers", "paused", "replicas", "selector", "strategy", "tags"},
+			"properties": schema{
+				"containers": schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+				"paused":     schema{"type": "boolean"},
+				"replicas":   schema{"type": "integer", "default": 1},
+				"selector":   schema{"type": "object", "additionalProperties": str()},
+				"strategy":   ref("io.k8s.api.apps.v1.Strategy"),
+				"tags":       schema{"type": "array", "items": str()},
+			},
+		},
+		"io.k8s.api.core.v1.Container": schema{
+			"type":       "object",
+			"required":   []string{"image", "name"},
+			"properties": schema{"image": str(), "name": str(), "command": str()},
+		},
+		"io.k8s.api.apps.v1.Strategy": object(schema{"type": str()}),
+	})
+}
+
+func TestAutoExampleSkeleton(t *testing.T) {
+	d := newAutoExampleDefinitions(t)
+	expected := `apiVersion: apps/v1
+kind: Deployment
+spec:
+  containers:
+  - image: "string"
+    name: "string"
+  paused: false
+  replicas: 1
+  selector: {}
+  strategy: {}
+  tags: ["string"]
+`
+	if got := AutoExampleProvider().GetSample(mustGet(t, &d, "apps.v1.Deployment")); got != expected {
+		t.Errorf("GetSample() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestAutoExampleKeepsConfiguredSample(t *testing.T) {
+	d := newAutoExampleDefinitions(t)
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	deployment.Sample.Sample = "kind: Deployment\n"
+	if got := AutoExampleProvider().GetSample(deployment); got != deployment.Sample.Sample {
+		t.Errorf("GetSample() = %q, want the configured sample", got)
+	}
+}
+
+func TestAutoExamplesFlag(t *testing.T) {
+	d := newAutoExampleDefinitions(t)
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	setFlag(t, "build-operations", "false")
+	if deployment.HasSamples() {
+		t.Errorf("HasSamples() without --auto-examples = true, want false")
+	}
+	setFlag(t, "auto-examples", "true")
+	if _, ok := GetExampleProviders()[0].(*AutoExample); !ok {
+		t.Errorf("GetExampleProviders() = %v, want the AutoExample provider", GetExampleProviders())
+	}
+	if !deployment.HasSamples() {
+		t.Errorf("HasSamples() with --auto-examples = false, want true")
+	}
+}
+
+func TestAutoExampleDefaults(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": schema{
+			"type":     "object",
+			"required": []string{"spec"},
+			"properties": schema{
+				"spec": ref("io.k8s.api.apps.v1.DeploymentSpec"),
+			},
+		},
+		"io.k8s.api.apps.v1.DeploymentSpec": schema{
+			"type":     "object",
+			"required": []string{"restartPolicy"},
+			"properties": schema{
+				"restartPolicy": ref("io.k8s.api.core.v1.RestartPolicy"),
+			},
+			"allOf": []schema{ref("io.k8s.api.apps.v1.Scaling")},
+		},
+		"io.k8s.api.apps.v1.Scaling": schema{
+			"type":       "object",
+			"required":   []string{"replicas"},
+			"properties": schema{"replicas": schema{"type": "integer", "default": 3}},
+		},
+		"io.k8s.api.core.v1.RestartPolicy": schema{"type": "string", "default": "Always"},
+	})
+	expected := `apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: 3
+  restartPolicy: "Always"
+`
+	if got := AutoExampleProvider().GetSample(mustGet(t, &d, "apps.v1.Deployment")); got != expected {
+		t.Errorf("GetSample() =\n%s\nwant\n%s", got, expected)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"flag"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+)
+
+var DocsSpecDir = flag.String("docs-spec-dir", "", "Directory of open-api documents whose descriptions fill in empty descriptions of the same definitions and fields.")
+
+// GetDefinitionsWithDocs returns the Definitions for specs with empty descriptions filled in from docs
+func GetDefinitionsWithDocs(specs, docs []*loads.Document) Definitions {
+	d := GetDefinitions(specs)
+	d.BackfillDescriptions(docs)
+	return d
+}
+
+// BackfillDescriptions fills in the empty descriptions of definitions and their properties from the definitions
+// of docs with the same full name.  Descriptions that are already set in the spec are left unchanged.  Fields are
+// rebuilt afterwards so that descriptions inherited with --inherit-descriptions only fill in fields still empty.
+func (d *Definitions) BackfillDescriptions(docs []*loads.Document) {
+	byFullName := map[string]*Definition{}
+	for _, definition := range d.GetAllDefinitions() {
+		if len(definition.FullName) > 0 {
+			byFullName[definition.FullName] = definition
+		}
+	}
+	for _, doc := range docs {
+		for name, s := range doc.Spec().Definitions {
+			definition, found := byFullName[name]
+			if !found {
+				continue
+			}
+			if len(definition.schema.Description) == 0 {
+				definition.schema.Description = s.Description
+			}
+			// Copy the properties so the documents the definitions were loaded from are unchanged
+			properties := map[string]spec.Schema{}
+			for propertyName, property := range definition.schema.Properties {
+				if len(property.Description) == 0 {
+					property.Description = s.Properties[propertyName].Description
+				}
+				properties[propertyName] = property
+			}
+			definition.schema.Properties = properties
+		}
+	}
+	d.RebuildRelationships()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/loads"
+)
+
+func TestBackfillDescriptions(t *testing.T) {
+	setFlag(t, "inherit-descriptions", "true")
+	setFlag(t, "index-descriptions", "true")
+	specs := newSpec(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"spec":     ref("io.k8s.api.core.v1.PodSpec"),
+			"metadata": ref("io.k8s.api.core.v1.ObjectMeta"),
+			"kind":     describedStr("Kind of the object."),
+		}),
+		"io.k8s.api.core.v1.PodSpec":    schema{"type": "object", "description": "PodSpec is a description of a pod."},
+		"io.k8s.api.core.v1.ObjectMeta": schema{"type": "object", "description": "Standard object metadata."},
+	})
+	d := GetDefinitions([]*loads.Document{specs})
+	if index := d.SearchIndex(); len(index["desired"]) != 0 {
+		t.Fatalf("index[desired] = %v before backfilling, want none", index["desired"])
+	}
+
+	docs := newSpec(t, schema{
+		"io.k8s.api.core.v1.Pod": schema{
+			"type":        "object",
+			"description": "Pod is a collection of containers.",
+			"properties": schema{
+				"spec": describedStr("Specification of the desired\nbehavior of the pod."),
+				"kind": describedStr("Ignored."),
+			},
+		},
+	})
+	d.BackfillDescriptions([]*loads.Document{docs})
+
+	pod := mustGet(t, &d, "core.v1.Pod")
+	if got := pod.Description(); got != "Pod is a collection of containers." {
+		t.Errorf("Pod Description() = %q, want the docs description", got)
+	}
+	tests := map[string]string{
+		"spec":     "Specification of the desired behavior of the pod.",
+		"metadata": "Standard object metadata.",
+		"kind":     "Kind of the object.",
+	}
+	for name, expected := range tests {
+		if field, _ := pod.GetField(name); field.Description != expected {
+			t.Errorf("%s Description = %q, want %q", name, field.Description, expected)
+		}
+	}
+	expected := []SearchResult{{Type: SearchField, Key: "core.v1.Pod", Field: "spec"}}
+	if got := d.SearchIndex()["desired"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("index[desired] after backfilling = %v, want %v", got, expected)
+	}
+
+	if description := specs.Spec().Definitions["io.k8s.api.core.v1.Pod"].Properties["spec"].Description; description != "" {
+		t.Errorf("BackfillDescriptions() changed the loaded spec to %q", description)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"gopkg.in/yaml.v2"
@@ -73,7 +74,10 @@ func (config *Config) genConfigFromTags(specs []*loads.Document) {
 	}
 }
 
-func NewConfig() *Config {
+// NewConfig loads the config and the definitions of the open-api specs.  Returns an error if the definitions
+// could not be built, e.g. if --fail-on-unresolved is set and fields reference missing definitions, unless
+// --allow-errors is set.
+func NewConfig() (*Config, error) {
 	config := loadYamlConfig()
 	specs := LoadOpenApiSpec()
 
@@ -82,7 +86,22 @@ func NewConfig() *Config {
 	}
 
 	// Initialize all of the operations
-	config.Definitions = GetDefinitions(specs)
+	definitions, err := GetDefinitionsContext(context.Background(), specs)
+	if err != nil {
+		if !*AllowErrors {
+			return nil, err
+		}
+		os.Stderr.WriteString(fmt.Sprintf("Error: %v.\n", err))
+	}
+	if len(*DocsSpecDir) > 0 {
+		docs, err := LoadSpecDir(*DocsSpecDir)
+		if err != nil {
+			os.Stderr.WriteString(fmt.Sprintf("%v", err))
+			os.Exit(1)
+		}
+		definitions.BackfillDescriptions(docs)
+	}
+	config.Definitions = definitions
 
 	// Initialization for ToC resources only
 	vistToc := func(resource *Resource, definition *Definition) {
@@ -116,7 +135,7 @@ func NewConfig() *Config {
 		config.ResourceCategories = categories
 	}
 
-	return config
+	return config, nil
 }
 
 func verifyBlacklisted(operation Operation) {
@@ -188,15 +207,12 @@ func GetGroupVersionKindSub(o *Operation) (string, string, string, string) {
 	return "", "", "", ""
 }
 
+// GetResourceName returns the resource name of the definition, defaulting to the lowercase plural of its kind
 func GetResourceName(d *Definition) string {
 	if len(d.Resource) > 0 {
 		return d.Resource
 	}
-	resource := strings.ToLower(d.Name)
-	if strings.HasSuffix(resource, "y") {
-		return strings.TrimSuffix(resource, "y") + "ies"
-	}
-	return resource + "s"
+	return strings.ToLower(Pluralize(d.Name))
 }
 
 func (config *Config) initOperationsFromTags(specs []*loads.Document) {
@@ -275,6 +291,7 @@ func (config *Config) InitOperations(specs []*loads.Document) {
 		}
 	})
 	config.Definitions.initializeOperationParameters(config.Operations)
+	config.initWatchSupported()
 
 	// Clear the operations.  We still have to calculate the operations because that is how we determine
 	// the API Group for each definition.
@@ -287,11 +304,25 @@ func (config *Config) InitOperations(specs []*loads.Document) {
 	}
 }
 
+// initWatchSupported flags the definitions with a watch operation
+func (config *Config) initWatchSupported() {
+	for _, d := range config.Definitions.GetAllDefinitions() {
+		d.WatchSupported = false
+		for _, oc := range d.OperationCategories {
+			for _, o := range oc.Operations {
+				if o.IsWatch() {
+					d.WatchSupported = true
+				}
+			}
+		}
+	}
+}
+
 // CleanUp sorts and dedups fields
 func (c *Config) CleanUp() {
 	for _, d := range c.Definitions.GetAllDefinitions() {
 		sort.Sort(d.AppearsIn)
-		sort.Sort(d.Fields)
+		d.SortFields()
 		dedup := SortDefinitionsByName{}
 		last := ""
 		for _, i := range d.AppearsIn {
@@ -305,6 +336,53 @@ func (c *Config) CleanUp() {
 	}
 }
 
+// initSettings adds the settings read from config.yaml to the package level settings.  Settings already present
+// are not added again, so loading the config more than once leaves them unchanged.
+func (c *Config) initSettings() {
+	mergeStrings(DefinitionReplacements, c.DefinitionReplacements)
+	mergeStrings(GroupFullNames, c.GroupFullNames)
+	mergeStrings(PatternHints, c.PatternHints)
+	mergeBools(NamespacedKinds, c.NamespacedKinds)
+	mergeBools(ConventionRules, c.ConventionRules)
+	for _, g := range c.ExperimentalGroups {
+		ExperimentalGroups[g] = true
+	}
+	for k, v := range c.TocWeights {
+		TocWeights[k] = v
+	}
+	DefinitionPrefixes = addStrings(DefinitionPrefixes, c.DefinitionPrefixes)
+	DeprecatedRefPrefixes = addStrings(DeprecatedRefPrefixes, c.DeprecatedRefPrefixes)
+}
+
+// mergeStrings sets the values of from in to
+func mergeStrings(to, from map[string]string) {
+	for k, v := range from {
+		to[k] = v
+	}
+}
+
+// mergeBools sets the values of from in to
+func mergeBools(to, from map[string]bool) {
+	for k, v := range from {
+		to[k] = v
+	}
+}
+
+// addStrings appends the values of from missing from to
+func addStrings(to, from []string) []string {
+	found := map[string]bool{}
+	for _, s := range to {
+		found[s] = true
+	}
+	for _, s := range from {
+		if !found[s] {
+			found[s] = true
+			to = append(to, s)
+		}
+	}
+	return to
+}
+
 // loadYamlConfig reads the config yaml file into a struct
 func loadYamlConfig() *Config {
 	f := filepath.Join(*ConfigDir, "config.yaml")
@@ -322,6 +400,7 @@ func loadYamlConfig() *Config {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		config.initSettings()
 	}
 
 	writeCategory := OperationCategory{
@@ -0,0 +1,351 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// withConfig writes contents to config.yaml in a temporary config dir and loads it
+func withConfig(t *testing.T, contents string) *Config {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.yaml"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setFlag(t, "config-dir", dir)
+	return loadYamlConfig()
+}
+
+// saveSettings restores the package level settings added to by config.yaml when the test ends
+func saveSettings(t *testing.T) {
+	replacements, fullNames, hints := DefinitionReplacements, GroupFullNames, PatternHints
+	namespaced, rules, experimental := NamespacedKinds, ConventionRules, ExperimentalGroups
+	weights, prefixes, deprecated := TocWeights, DefinitionPrefixes, DeprecatedRefPrefixes
+	t.Cleanup(func() {
+		DefinitionReplacements, GroupFullNames, PatternHints = replacements, fullNames, hints
+		NamespacedKinds, ConventionRules, ExperimentalGroups = namespaced, rules, experimental
+		TocWeights, DefinitionPrefixes, DeprecatedRefPrefixes = weights, prefixes, deprecated
+	})
+	DefinitionReplacements, GroupFullNames, PatternHints = map[string]string{}, map[string]string{}, map[string]string{}
+	mergeStrings(GroupFullNames, fullNames)
+	NamespacedKinds, ConventionRules, ExperimentalGroups = map[string]bool{}, map[string]bool{}, map[string]bool{}
+	mergeBools(ConventionRules, rules)
+	TocWeights = map[string]int{}
+	DefinitionPrefixes, DeprecatedRefPrefixes = addStrings(nil, prefixes), []string{}
+}
+
+func TestConfigSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		definitions schema
+		check       func(t *testing.T, d *Definitions)
+	}{
+		{
+			name: "definition_replacements",
+			config: `
+definition_replacements:
+  extensions.v1beta1.Deployment: apps.v1beta1.Deployment
+`,
+			definitions: schema{
+				"io.k8s.api.extensions.v1beta1.Deployment": object(schema{"kind": str()}),
+				"io.k8s.api.apps.v1beta1.Deployment":       object(schema{"kind": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				if got := mustGet(t, d, "extensions.v1beta1.Deployment").ReplacedBy; got != mustGet(t, d, "apps.v1beta1.Deployment") {
+					t.Errorf("ReplacedBy = %v, want apps.v1beta1.Deployment", got)
+				}
+			},
+		},
+		{
+			name: "definition_prefixes",
+			config: `
+definition_prefixes:
+  - com.acme
+`,
+			definitions: schema{
+				"com.acme.api.widgets.v1.Widget": object(schema{"size": str()}),
+				"io.k8s.api.core.v1.Pod":         object(schema{"kind": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				if got := mustGet(t, d, "widgets.v1.Widget").Name; got != "Widget" {
+					t.Errorf("Name = %q, want Widget", got)
+				}
+				mustGet(t, d, "core.v1.Pod")
+			},
+		},
+		{
+			name: "experimental_groups",
+			config: `
+experimental_groups:
+  - settings
+`,
+			definitions: schema{
+				"io.k8s.api.core.v1.PodSpec": object(schema{
+					"preset":   ref("io.k8s.api.settings.v1alpha1.PodPreset"),
+					"affinity": ref("io.k8s.api.core.v1.Affinity"),
+				}),
+				"io.k8s.api.settings.v1alpha1.PodPreset": object(schema{"kind": str()}),
+				"io.k8s.api.core.v1.Affinity":            object(schema{"kind": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				podSpec := mustGet(t, d, "core.v1.PodSpec")
+				if field, _ := podSpec.GetField("preset"); !field.Experimental {
+					t.Errorf("preset Experimental = false, want true")
+				}
+				if field, _ := podSpec.GetField("affinity"); field.Experimental {
+					t.Errorf("affinity Experimental = true, want false")
+				}
+			},
+		},
+		{
+			name: "group_full_names",
+			config: `
+group_full_names:
+  acme: acme.example.com
+`,
+			definitions: schema{
+				"io.k8s.api.acme.v1.Widget": object(schema{"kind": str()}),
+				"io.k8s.api.core.v1.Pod":    object(schema{"kind": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				if got := mustGet(t, d, "acme.v1.Widget").GroupFullName(); got != "acme.example.com" {
+					t.Errorf("GroupFullName() = %q, want acme.example.com", got)
+				}
+				if got := mustGet(t, d, "core.v1.Pod").GroupFullName(); got != "" {
+					t.Errorf("core GroupFullName() = %q, want \"\"", got)
+				}
+			},
+		},
+		{
+			name: "namespaced_kinds",
+			config: `
+namespaced_kinds:
+  Widget: true
+  Gadget: false
+`,
+			definitions: schema{
+				"io.k8s.api.acme.v1.Widget": object(schema{"kind": str()}),
+				"io.k8s.api.acme.v1.Gadget": object(schema{"kind": str()}),
+				"io.k8s.api.acme.v1.Gizmo":  object(schema{"kind": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				for key, expected := range map[string]string{"acme.v1.Widget": "Yes", "acme.v1.Gadget": "No", "acme.v1.Gizmo": ""} {
+					if got := mustGet(t, d, key).NamespacedDisplay(); got != expected {
+						t.Errorf("%s NamespacedDisplay() = %q, want %q", key, got, expected)
+					}
+				}
+			},
+		},
+		{
+			name: "deprecated_ref_prefixes",
+			config: `
+deprecated_ref_prefixes:
+  - extensions.v1beta1.
+`,
+			definitions: schema{
+				"io.k8s.api.apps.v1.DeploymentSpec": object(schema{
+					"strategy": ref("io.k8s.api.extensions.v1beta1.DeploymentStrategy"),
+					"template": ref("io.k8s.api.core.v1.PodTemplateSpec"),
+				}),
+				"io.k8s.api.extensions.v1beta1.DeploymentStrategy": object(schema{"type": str()}),
+				"io.k8s.api.core.v1.PodTemplateSpec":               object(schema{"spec": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				spec := mustGet(t, d, "apps.v1.DeploymentSpec")
+				if field, _ := spec.GetField("strategy"); !field.UsesDeprecatedType || field.DeprecatedType != "extensions.v1beta1.DeploymentStrategy" {
+					t.Errorf("strategy UsesDeprecatedType = %v, DeprecatedType = %q, want extensions.v1beta1.DeploymentStrategy",
+						field.UsesDeprecatedType, field.DeprecatedType)
+				}
+				if field, _ := spec.GetField("template"); field.UsesDeprecatedType {
+					t.Errorf("template UsesDeprecatedType = true, want false")
+				}
+			},
+		},
+		{
+			name: "pattern_hints",
+			config: `
+pattern_hints:
+  "^[0-9]+[a-z]$": "a number followed by a unit"
+`,
+			definitions: schema{
+				"io.k8s.api.core.v1.Probe": object(schema{"period": schema{"type": "string", "pattern": "^[0-9]+[a-z]$"}}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				if field, _ := mustGet(t, d, "core.v1.Probe").GetField("period"); field.PatternHint() != "a number followed by a unit" {
+					t.Errorf("PatternHint() = %q, want the configured hint", field.PatternHint())
+				}
+			},
+		},
+		{
+			name: "convention_rules",
+			config: `
+convention_rules:
+  field-camel-case: false
+  bool-prefix: true
+`,
+			definitions: schema{
+				"io.k8s.api.core.v1.PodSpec": object(schema{
+					"host_name": str(),
+					"paused":    schema{"type": "boolean"},
+				}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				got := []string{}
+				for _, issue := range d.ConventionIssues() {
+					got = append(got, issue.Rule+" "+issue.Field)
+				}
+				if expected := []string{"bool-prefix paused"}; !reflect.DeepEqual(got, expected) {
+					t.Errorf("ConventionIssues() = %v, want %v", got, expected)
+				}
+			},
+		},
+		{
+			name: "toc_weights",
+			config: `
+toc_weights:
+  Service: 20
+  core.v1.Pod: 10
+`,
+			definitions: schema{
+				"io.k8s.api.core.v1.Pod":         object(schema{"kind": str()}),
+				"io.k8s.api.core.v1.Service":     object(schema{"kind": str()}),
+				"io.k8s.api.apps.v1.Deployment":  object(schema{"kind": str()}),
+				"io.k8s.api.apps.v1.StatefulSet": object(schema{"kind": str()}),
+			},
+			check: func(t *testing.T, d *Definitions) {
+				for _, definition := range d.GetAllDefinitions() {
+					definition.InToc = true
+				}
+				expected := []string{"core.v1.Pod", "core.v1.Service", "apps.v1.Deployment", "apps.v1.StatefulSet"}
+				if got := keys(d.TocDefinitionsWeighted()); !reflect.DeepEqual(got, expected) {
+					t.Errorf("TocDefinitionsWeighted() = %v, want %v", got, expected)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			saveSettings(t)
+			withConfig(t, test.config)
+			d := newDefinitions(t, test.definitions)
+			test.check(t, &d)
+		})
+	}
+}
+
+func TestConfigLoadedTwice(t *testing.T) {
+	saveSettings(t)
+	contents := `
+definition_prefixes:
+  - com.acme
+  - com.acme
+deprecated_ref_prefixes:
+  - extensions.v1beta1.
+`
+	withConfig(t, contents)
+	withConfig(t, contents)
+	if expected := []string{"io.k8s", "com.acme"}; !reflect.DeepEqual(DefinitionPrefixes, expected) {
+		t.Errorf("DefinitionPrefixes = %v, want %v", DefinitionPrefixes, expected)
+	}
+	if expected := []string{"extensions.v1beta1."}; !reflect.DeepEqual(DeprecatedRefPrefixes, expected) {
+		t.Errorf("DeprecatedRefPrefixes = %v, want %v", DeprecatedRefPrefixes, expected)
+	}
+}
+
+func TestGetResourceName(t *testing.T) {
+	tests := map[string]string{
+		"Deployment":    "deployments",
+		"NetworkPolicy": "networkpolicies",
+		"Gateway":       "gateways",
+		"Endpoints":     "endpoints",
+	}
+	for kind, expected := range tests {
+		if got := GetResourceName(&Definition{Name: kind}); got != expected {
+			t.Errorf("GetResourceName(%s) = %q, want %q", kind, got, expected)
+		}
+	}
+	if got := GetResourceName(&Definition{Name: "Deployment", Resource: "deploys"}); got != "deploys" {
+		t.Errorf("GetResourceName() with a resource = %q, want deploys", got)
+	}
+}
+
+func TestInitWatchSupported(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":             object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.ComponentStatus": object(schema{"kind": str()}),
+	})
+	pod := mustGet(t, &d, "core.v1.Pod")
+	pod.OperationCategories = []*OperationCategory{{
+		Name: "Read Operations",
+		Operations: []*Operation{
+			{ID: "readCoreV1NamespacedPod", Type: OperationType{Name: "Read"}},
+			{ID: "watchCoreV1NamespacedPod", Type: OperationType{Name: "Watch"}},
+		},
+	}}
+	status := mustGet(t, &d, "core.v1.ComponentStatus")
+	status.WatchSupported = true
+	status.OperationCategories = []*OperationCategory{{
+		Name:       "Read Operations",
+		Operations: []*Operation{{ID: "readCoreV1ComponentStatus", Type: OperationType{Name: "Read"}}},
+	}}
+
+	config := &Config{Definitions: d}
+	config.initWatchSupported()
+	if !pod.WatchSupported {
+		t.Errorf("Pod WatchSupported = false, want true")
+	}
+	if status.WatchSupported {
+		t.Errorf("ComponentStatus WatchSupported = true, want false")
+	}
+}
+
+func TestNewConfigFailOnUnresolved(t *testing.T) {
+	saveSettings(t)
+	withConfig(t, "")
+	b, err := json.Marshal(swagger(schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{"volume": ref("io.k8s.api.core.v1.Missing")}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(*ConfigDir, "openapi-spec")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "swagger.json"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	setFlag(t, "fail-on-unresolved", "true")
+	if config, err := NewConfig(); err == nil || config != nil {
+		t.Errorf("NewConfig() = %v, %v, want an error", config, err)
+	}
+	setFlag(t, "allow-errors", "true")
+	if _, err := NewConfig(); err != nil {
+		t.Errorf("NewConfig() with --allow-errors error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// RuleFieldCamelCase requires field names to be lower camel case e.g. "restartPolicy"
+	RuleFieldCamelCase = "field-camel-case"
+	// RuleKindPascalCase requires kinds to be upper camel case e.g. "PodSpec"
+	RuleKindPascalCase = "kind-pascal-case"
+	// RuleBoolPrefix requires boolean fields to start with one of BoolFieldPrefixes
+	RuleBoolPrefix = "bool-prefix"
+)
+
+// ConventionRules enables or disables each naming convention rule checked by ConventionIssues.  Rules set under
+// convention_rules in config.yaml override these.
+var ConventionRules = map[string]bool{
+	RuleFieldCamelCase: true,
+	RuleKindPascalCase: true,
+	RuleBoolPrefix:     false,
+}
+
+// BoolFieldPrefixes are the prefixes accepted for boolean field names by RuleBoolPrefix
+var BoolFieldPrefixes = []string{"is", "has", "allow", "enable", "use", "should"}
+
+var camelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+var pascalCase = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+// ConventionIssue is a violation of a naming convention by a definition or one of its fields
+type ConventionIssue struct {
+	Rule string
+	// Key is the key of the definition with the issue
+	Key string
+	// Field is the name of the field with the issue.  Empty for issues with the definition.
+	Field   string
+	Message string
+}
+
+type ConventionIssues []ConventionIssue
+
+func (a ConventionIssues) Len() int      { return len(a) }
+func (a ConventionIssues) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a ConventionIssues) Less(i, j int) bool {
+	if a[i].Key != a[j].Key {
+		return a[i].Key < a[j].Key
+	}
+	if a[i].Field != a[j].Field {
+		return a[i].Field < a[j].Field
+	}
+	return a[i].Rule < a[j].Rule
+}
+
+// ConventionIssues returns the violations of the enabled ConventionRules by the definitions and their fields
+func (d *Definitions) ConventionIssues() []ConventionIssue {
+	issues := ConventionIssues{}
+	for key, definition := range d.GetAllDefinitions() {
+		if ConventionRules[RuleKindPascalCase] && !pascalCase.MatchString(definition.Name) {
+			issues = append(issues, ConventionIssue{
+				Rule:    RuleKindPascalCase,
+				Key:     key,
+				Message: fmt.Sprintf("kind %s is not PascalCase", definition.Name),
+			})
+		}
+		for _, field := range definition.Fields {
+			if ConventionRules[RuleFieldCamelCase] && !camelCase.MatchString(field.Name) {
+				issues = append(issues, ConventionIssue{
+					Rule:    RuleFieldCamelCase,
+					Key:     key,
+					Field:   field.Name,
+					Message: fmt.Sprintf("field %s is not camelCase", field.Name),
+				})
+			}
+			if ConventionRules[RuleBoolPrefix] && field.Type == "boolean" && !hasAnyPrefix(field.Name, BoolFieldPrefixes) {
+				issues = append(issues, ConventionIssue{
+					Rule:    RuleBoolPrefix,
+					Key:     key,
+					Field:   field.Name,
+					Message: fmt.Sprintf("boolean field %s does not start with one of %s", field.Name, strings.Join(BoolFieldPrefixes, ", ")),
+				})
+			}
+		}
+	}
+	sort.Sort(issues)
+	return issues
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConventionIssues(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"restartPolicy":  str(),
+			"host_name":      str(),
+			"paused":         schema{"type": "boolean"},
+			"hostNetwork":    schema{"type": "boolean"},
+			"enableDebugger": schema{"type": "boolean"},
+		}),
+		"io.k8s.api.core.v1.Pod": object(schema{"kind": str(), "apiVersion": str()}),
+	})
+	expected := []ConventionIssue{{
+		Rule:    RuleFieldCamelCase,
+		Key:     "core.v1.PodSpec",
+		Field:   "host_name",
+		Message: "field host_name is not camelCase",
+	}}
+	if got := d.ConventionIssues(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ConventionIssues() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestConventionIssuesWellFormed(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{"kind": str(), "apiVersion": str(), "isReady": schema{"type": "boolean"}}),
+	})
+	if got := d.ConventionIssues(); len(got) != 0 {
+		t.Errorf("ConventionIssues() = %+v, want none", got)
+	}
+}
@@ -17,25 +17,162 @@ limitations under the License.
 package api
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
-	"errors"
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
 )
 
+var OtherVersionsAcrossGroups = flag.Bool("other-versions-across-groups", false, "If true, definitions of the same kind in other groups are listed as other versions.")
+var HideStatus = flag.Bool("hide-status", false, "If true, omit the status field from definitions.")
+var InheritDescriptions = flag.Bool("inherit-descriptions", false, "If true, fields without a description use the description of the definition they reference.")
+
+// ProgressFunc is called with the number of items done out of total for a stage of building definitions.
+// Stages are "parsing", "fields" and "relationships".
+type ProgressFunc func(stage string, done, total int)
+
+// Progress is called periodically while building definitions if set
+var Progress ProgressFunc
+
+// reportProgress calls Progress about 20 times over the course of a stage
+func reportProgress(stage string, done, total int) {
+	if Progress == nil {
+		return
+	}
+	interval := total / 20
+	if interval < 1 {
+		interval = 1
+	}
+	if done%interval == 0 || done == total {
+		Progress(stage, done, total)
+	}
+}
+
+// NamespacedKinds sets whether kinds are namespaced for definitions without a scope extension.  Entries under
+// namespaced_kinds in config.yaml are added.
+var NamespacedKinds = map[string]bool{}
+
+// ExperimentalGroups is the set of api groups guarded by feature gates.  Fields referencing definitions in these
+// groups are marked as Experimental.  Groups listed under experimental_groups in config.yaml are added.
+var ExperimentalGroups = map[string]bool{}
+
+// DeprecatedRefPrefixes are prefixes of definition names (e.g. "io.k8s.kubernetes.pkg.apis.extensions.v1beta1.")
+// or keys (e.g. "extensions.v1beta1.") of deprecated types.  Fields referencing them are marked UsesDeprecatedType.
+// Prefixes listed under deprecated_ref_prefixes in config.yaml are added.
+var DeprecatedRefPrefixes = []string{}
+
 // Definitions indexes open-api definitions
 type Definitions struct {
 	ByGroupVersionKind map[string]*Definition
 	ByKind             map[string]SortDefinitionsByVersion
+
+	searchIndex map[string][]SearchResult
 }
 
 func (d *Definitions) GetAllDefinitions() map[string]*Definition {
 	return d.ByGroupVersionKind
 }
 
+// CurrentVersionsOnly returns the current version of each kind sorted by name.  The current version is the first
+// of the kind in ByKind, i.e. the newest version, with ties between groups won by the group sorting first.
+func (d *Definitions) CurrentVersionsOnly() []*Definition {
+	current := SortDefinitionsByName{}
+	for kind, versions := range d.ByKind {
+		if len(versions) == 0 || versions[0].IsOldVersion {
+			os.Stderr.WriteString(fmt.Sprintf("Could not find a current version of %s: all versions are marked old.\n", kind))
+			continue
+		}
+		current = append(current, versions[0])
+	}
+	sort.Sort(current)
+	return current
+}
+
+// GroupFullNames maps the short group names found in definition names to the full group names used in the
+// apiVersion of objects.  Groups missing from the map are used as is.  Entries under group_full_names in
+// config.yaml are added.
+var GroupFullNames = map[string]string{
+	"core":           "",
+	"apps":           "apps",
+	"authentication": "authentication.k8s.io",
+	"authorization":  "authorization.k8s.io",
+	"autoscaling":    "autoscaling",
+	"batch":          "batch",
+	"certificates":   "certificates.k8s.io",
+	"extensions":     "extensions",
+	"policy":         "policy",
+	"rbac":           "rbac.authorization.k8s.io",
+	"settings":       "settings.k8s.io",
+	"storage":        "storage.k8s.io",
+}
+
+// TocDefinitions returns the definitions appearing in the table of contents sorted by group and kind
+func (d *Definitions) TocDefinitions() []*Definition {
+	toc := SortDefinitionsByGroupKind{}
+	for _, definition := range d.GetAllDefinitions() {
+		if definition.IsInToc() {
+			toc = append(toc, definition)
+		}
+	}
+	sort.Sort(toc)
+	return toc
+}
+
+// StandaloneDefinitions returns the definitions documented in the definitions section sorted by name.  These
+// are the current versions of definitions that are neither in the table of contents nor inlined into one.
+func (d *Definitions) StandaloneDefinitions() []*Definition {
+	definitions := SortDefinitionsByName{}
+	for _, definition := range d.GetAllDefinitions() {
+		if definition.IsStandalone() {
+			definitions = append(definitions, definition)
+		}
+	}
+	sort.Sort(definitions)
+	return definitions
+}
+
+// DefaultTocWeight is the weight of definitions not found in TocWeights
+const DefaultTocWeight = 50
+
+// TocWeights orders definitions in TocDefinitionsWeighted.  Definitions with lower weights appear first.
+// Weights are looked up by key e.g. "apps.v1beta1.Deployment" and then by kind e.g. "Deployment".  Weights
+// under toc_weights in config.yaml are added.
+var TocWeights = map[string]int{}
+
+// TocWeight returns the weight of the definition in TocWeights
+func (d *Definition) TocWeight() int {
+	if w, found := TocWeights[d.Key()]; found {
+		return w
+	}
+	if w, found := TocWeights[d.Name]; found {
+		return w
+	}
+	return DefaultTocWeight
+}
+
+// TocDefinitionsWeighted returns the definitions in TocDefinitions ordered by TocWeights and then by name
+func (d *Definitions) TocDefinitionsWeighted() []*Definition {
+	toc := SortDefinitionsByWeight(d.TocDefinitions())
+	sort.Stable(toc)
+	return toc
+}
+
+// MissingSamples returns the table of contents definitions for which no example provider returns a sample
+func (d *Definitions) MissingSamples() []*Definition {
+	missing := []*Definition{}
+	for _, definition := range d.TocDefinitions() {
+		if !definition.HasSamples() {
+			missing = append(missing, definition)
+		}
+	}
+	return missing
+}
+
 func (d *Definition) GroupDisplayName() string {
 	if len(d.Group) <= 0 || d.Group == "core" {
 		return "Core"
@@ -43,10 +180,15 @@ func (d *Definition) GroupDisplayName() string {
 	return string(d.Group)
 }
 
+// GetOtherVersions returns the definitions of the same kind and group with a different version.  Definitions in
+// other groups are included with --other-versions-across-groups.
 func (d *Definitions) GetOtherVersions(this *Definition) []*Definition {
 	defs := d.ByKind[this.Name]
 	others := []*Definition{}
 	for _, def := range defs {
+		if def.Group != this.Group && !*OtherVersionsAcrossGroups {
+			continue
+		}
 		if def.Version != this.Version {
 			others = append(others, def)
 		}
@@ -73,6 +215,16 @@ func (d *Definitions) IsComplex(s spec.Schema) bool {
 	return len(k) > 0
 }
 
+// ResolveRef looks up the definition referenced by an open-api v2 "#/definitions/..." or
+// v3 "#/components/schemas/..." reference
+func (d *Definitions) ResolveRef(ref spec.Ref) (*Definition, bool) {
+	g, v, k, err := parseDefinitionName(definitionNameForRef(ref))
+	if err != nil || len(k) == 0 {
+		return nil, false
+	}
+	return d.GetByVersionKind(g, v, k)
+}
+
 func (d *Definitions) GetForSchema(s spec.Schema) (*Definition, bool) {
 	g, v, k := GetDefinitionVersionKind(s)
 	if len(k) <= 0 {
@@ -87,38 +239,190 @@ func (d *Definitions) Put(defintion *Definition) {
 
 // Initializes the fields for all definitions
 func (d *Definitions) InitializeFieldsForAll() {
+	d.initializeFieldsForAll(context.Background())
+}
+
+// initializeFieldsForAll initializes the fields for all definitions, replacing any initialized before.
+// Stops early if ctx is done.
+func (d *Definitions) initializeFieldsForAll(ctx context.Context) error {
+	done, total := 0, len(d.GetAllDefinitions())
 	for _, definition := range d.GetAllDefinitions() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		definition.Fields = nil
+		definition.ShadowedFields = nil
 		d.InitializeFields(definition)
+		done++
+		reportProgress("fields", done, total)
 	}
+	return nil
 }
 
-const patchStrategyKey = "x-kubernetes-patch-strategy"
-const patchMergeKeyKey = "x-kubernetes-patch-merge-key"
-const resourceNameKey = "x-kubernetes-resource"
+const validationsKey = "x-kubernetes-validations"
+
+var PatchStrategyKey = flag.String("patch-strategy-key", "x-kubernetes-patch-strategy", "Extension containing the patch strategy of a field.")
+var PatchMergeKeyKey = flag.String("patch-merge-key-key", "x-kubernetes-patch-merge-key", "Extension containing the patch merge key of a field.")
+var ResourceNameKey = flag.String("resource-key", "x-kubernetes-resource", "Extension containing the resource name of a definition.")
+var ScopeKey = flag.String("scope-key", "x-kubernetes-scope", "Extension containing the scope of a definition, either \"Namespaced\" or \"Cluster\".")
+var EnumDescriptionsKey = flag.String("enum-descriptions-key", "x-kubernetes-enum-descriptions", "Extension containing the descriptions of the enum values of a field, either a list aligned with the values or a map from value to description.")
+var PropertyOrderKey = flag.String("property-order-key", "x-kubernetes-property-order", "Extension containing the authored order of the properties of a definition.")
+var ImmutableKey = flag.String("immutable-key", "x-kubernetes-immutable", "Boolean extension marking a field as immutable.")
 
-// Initializes the fields for a definition
+// Initializes the fields for a definition.  Fields composed into the definition with allOf are merged
+// with the fields it declares, with the most derived declaration of a field taking precedence.
 func (d *Definitions) InitializeFields(definition *Definition) {
-	for fieldName, property := range definition.schema.Properties {
+	found := map[string]bool{}
+	for _, field := range append(d.schemaFields(definition.schema), d.composedFields(definition.schema, maxAllOfDepth)...) {
+		if found[field.Name] {
+			definition.ShadowedFields = append(definition.ShadowedFields, field)
+			continue
+		}
+		found[field.Name] = true
+		definition.Fields = append(definition.Fields, field)
+	}
+	d.initializeAdditional(definition)
+	definition.SortFields()
+}
+
+// SortFields orders the fields of the definition by the property order extension of its schema.  Fields not
+// listed in the extension follow in alphabetical order.  All fields are ordered alphabetically without it.
+func (d *Definition) SortFields() {
+	sort.Sort(d.Fields)
+	order, found := d.schema.Extensions.GetStringSlice(*PropertyOrderKey)
+	if !found || len(order) == 0 {
+		return
+	}
+	position := map[string]int{}
+	for i, name := range order {
+		if _, found := position[name]; !found {
+			position[name] = i
+		}
+	}
+	sort.Stable(fieldsByPosition{d.Fields, position})
+}
+
+// fieldsByPosition orders fields found in position by their position, before all other fields
+type fieldsByPosition struct {
+	Fields
+	position map[string]int
+}
+
+func (a fieldsByPosition) Less(i, j int) bool {
+	pi, foundI := a.position[a.Fields[i].Name]
+	pj, foundJ := a.position[a.Fields[j].Name]
+	if foundI && foundJ {
+		return pi < pj
+	}
+	return foundI && !foundJ
+}
+
+// initializeAdditional records whether a definition with named properties also allows additional properties
+func (d *Definitions) initializeAdditional(definition *Definition) {
+	s := definition.schema
+	if len(s.Properties) == 0 || s.AdditionalProperties == nil {
+		return
+	}
+	additional := s.AdditionalProperties
+	if additional.Schema == nil && !additional.Allows {
+		return
+	}
+	// additionalProperties: true allows values of any type
+	definition.AllowsAdditional = true
+	definition.AdditionalType = "any"
+	if additional.Schema == nil {
+		return
+	}
+	if IsDefinition(*additional.Schema) || IsArray(*additional.Schema) || len(additional.Schema.Type) > 0 {
+		definition.AdditionalType = GetTypeName(*additional.Schema)
+	}
+	definition.AdditionalDefinition, _ = d.GetForSchema(*additional.Schema)
+}
+
+// maxAllOfDepth bounds the depth of allOf composition that is followed
+const maxAllOfDepth = 10
+
+// composedFields returns the fields of the schemas composed into s with allOf.  The fields of each
+// composed schema appear before the fields it in turn composes.
+func (d *Definitions) composedFields(s spec.Schema, depth int) Fields {
+	fields := Fields{}
+	if depth <= 0 {
+		return fields
+	}
+	for _, composed := range s.AllOf {
+		if base, found := d.GetForSchema(composed); found {
+			composed = base.schema
+		}
+		fields = append(fields, d.schemaFields(composed)...)
+		fields = append(fields, d.composedFields(composed, depth-1)...)
+	}
+	return fields
+}
+
+// schemaFields returns the fields for the properties declared by s
+func (d *Definitions) schemaFields(s spec.Schema) Fields {
+	fields := Fields{}
+	for fieldName, property := range s.Properties {
+		if isHiddenField(fieldName) {
+			continue
+		}
 		def := strings.Replace(property.Description, "\n", " ", -1)
 		field := &Field{
 			Name:        fieldName,
 			Type:        GetTypeName(property),
 			Description: def,
+			Required:    isRequired(s, fieldName),
+			Deprecated:  strings.HasPrefix(strings.ToLower(def), "deprecated"),
+
+			Minimum:          property.Minimum,
+			ExclusiveMinimum: property.ExclusiveMinimum,
+			Maximum:          property.Maximum,
+			ExclusiveMaximum: property.ExclusiveMaximum,
+			Pattern:          property.Pattern,
+			Enum:             enumValues(property),
+			Default:          property.Default,
+
+			kind: GetFieldKind(property),
 		}
-		if len(property.Extensions) > 0 {
-			if ps, f := property.Extensions.GetString(patchStrategyKey); f {
-				field.PatchStrategy = ps
-			}
-			if pmk, f := property.Extensions.GetString(patchMergeKeyKey); f {
-				field.PatchMergeKey = pmk
-			}
+		// Extension lookups don't need a guard: properties without extensions have a nil map, and
+		// lookups in it are not found.  Values of an unexpected type are also not found.
+		if ps, f := property.Extensions.GetString(*PatchStrategyKey); f {
+			field.PatchStrategy = ps
+		}
+		if pmk, f := property.Extensions.GetString(*PatchMergeKeyKey); f {
+			field.PatchMergeKey = pmk
 		}
+		field.Immutable = isImmutable(property)
+		field.EnumDescriptions = enumDescriptions(property, field.Enum)
 
 		if fieldDefinition, found := d.GetForSchema(property); found {
 			field.Definition = fieldDefinition
+			field.Experimental = ExperimentalGroups[fieldDefinition.Group.String()]
+			if field.Default == nil {
+				field.Default = fieldDefinition.schema.Default
+			}
 		}
-		definition.Fields = append(definition.Fields, field)
+		if IsMap(property) && property.AdditionalProperties.Schema != nil {
+			field.valueDefinition, _ = d.GetForSchema(*property.AdditionalProperties.Schema)
+		}
+		if name := referencedName(property); len(name) > 0 && len(field.referencedKey()) == 0 {
+			if _, _, k, err := parseDefinitionName(name); err == nil && len(k) > 0 {
+				field.unresolvedRef = name
+			}
+		}
+		for _, name := range []string{referencedName(property), field.referencedKey()} {
+			if len(name) > 0 && hasAnyPrefix(name, DeprecatedRefPrefixes) {
+				field.UsesDeprecatedType = true
+				field.DeprecatedType = name
+				break
+			}
+		}
+		if len(field.Description) == 0 && field.Definition != nil && *InheritDescriptions {
+			field.Description = strings.Replace(field.Definition.Description(), "\n", " ", -1)
+		}
+		fields = append(fields, field)
 	}
+	return fields
 }
 
 func (d *Definitions) InitializeOtherVersions() {
@@ -153,17 +457,68 @@ type Definition struct {
 	AppearsIn SortDefinitionsByName
 
 	OperationCategories []*OperationCategory
+	// WatchSupported is true if any of the operations of the definition is a watch.  Set by InitOperations.
+	WatchSupported bool
 
 	// Fields is a list of fields in this definition
 	Fields Fields
+	// AllowsAdditional is true if the definition allows properties other than its fields
+	AllowsAdditional bool
+	// AdditionalType is the type of the additional property values e.g. "string" or "any"
+	AdditionalType string
+	// AdditionalDefinition is the definition of the additional property values for complex types
+	AdditionalDefinition *Definition
+	// ShadowedFields are fields composed with allOf that are overridden by a more derived field of the same name
+	ShadowedFields Fields
 
 	OtherVersions SortDefinitionsByName
 	NewerVersions SortDefinitionsByName
 
+	// ReplacedBy is the definition that supersedes this one, possibly in another group
+	// e.g. apps/v1beta1 Deployment for extensions/v1beta1 Deployment
+	ReplacedBy *Definition
+
 	Sample SampleConfig
 
 	FullName string
 	Resource string
+	// InferredResource is the lowercase plural of the kind e.g. "statefulsets" when Resource is not set by the spec
+	InferredResource string
+	// Namespaced is true for namespaced resources, false for cluster scoped resources and nil if unknown
+	Namespaced *bool
+}
+
+// GroupFullName returns the full name of the definition's api group e.g. "rbac.authorization.k8s.io" for "rbac".
+// The core group's full name is empty.
+func (d *Definition) GroupFullName() string {
+	if full, found := GroupFullNames[d.Group.String()]; found {
+		return full
+	}
+	return d.Group.String()
+}
+
+// NamespacedDisplay returns "Yes" or "No" if it is known whether the definition is namespaced and "" otherwise
+func (d *Definition) NamespacedDisplay() string {
+	switch {
+	case d.Namespaced == nil:
+		return ""
+	case *d.Namespaced:
+		return "Yes"
+	default:
+		return "No"
+	}
+}
+
+// IsInToc returns true if the definition is listed in the table of contents.  Inlined definitions
+// and old versions are documented along side their parent and newest version respectively.
+func (d *Definition) IsInToc() bool {
+	return d.InToc && !d.IsInlined && !d.IsOldVersion
+}
+
+// IsStandalone returns true if the definition is documented in the definitions section instead of with a
+// table of contents entry
+func (d *Definition) IsStandalone() bool {
+	return !d.InToc && !d.IsInlined && !d.IsOldVersion
 }
 
 func (d *Definition) GetOperationGroupName() string {
@@ -177,78 +532,154 @@ func (d *Definition) Key() string {
 	return fmt.Sprintf("%s.%s.%s", d.Group, d.Version, d.Kind)
 }
 
-func (d *Definition) MdLink() string {
-	if *UseTags {
-		return fmt.Sprintf("[%s](#%s-%s)", d.Name, strings.ToLower(d.Name), d.Version)
+var AnchorPrefix = flag.String("anchor-prefix", "", "Prefix added to the anchors of definitions, e.g. \"api-\".")
+
+// Anchor returns the anchor of the definition that links point to
+func (d *Definition) Anchor() string {
+	return d.anchor(*UseTags)
+}
+
+// anchor returns the anchor of the definition.  Anchors built from tags omit the group.
+func (d *Definition) anchor(useTags bool) string {
+	if useTags {
+		return fmt.Sprintf("%s%s-%s", *AnchorPrefix, strings.ToLower(d.Name), d.Version)
 	}
-	return fmt.Sprintf("[%s](#%s-%s-%s)", d.Name, strings.ToLower(d.Name), d.Version, d.Group)
+	return fmt.Sprintf("%s%s-%s-%s", *AnchorPrefix, strings.ToLower(d.Name), d.Version, d.Group)
+}
+
+// AnchorPrefix returns the prefix added to definition anchors.  Templates emit explicit anchors when set, since the
+// anchors generated from the headings are not prefixed.
+func (d *Definition) AnchorPrefix() string {
+	return *AnchorPrefix
+}
 
+func (d *Definition) MdLink() string {
+	return fmt.Sprintf("[%s](#%s)", d.Name, d.Anchor())
 }
 
 func (d *Definition) HrefLink() string {
-	if *UseTags {
-		return fmt.Sprintf("<a href=\"#%s-%s\">%s</a>", strings.ToLower(d.Name), d.Version, d.Name)
-	}
-	return fmt.Sprintf("<a href=\"#%s-%s-%s\">%s</a>", strings.ToLower(d.Name), d.Version, d.Group, d.Name)
+	return fmt.Sprintf("<a href=\"#%s\">%s</a>", d.Anchor(), d.Name)
 }
 
 func (d *Definition) VersionLink() string {
-	if *UseTags {
-		return fmt.Sprintf("<a href=\"#%s-%s\">%s</a>", strings.ToLower(d.Name), d.Version, d.Version)
+	return fmt.Sprintf("<a href=\"#%s\">%s</a>", d.Anchor(), d.Version)
+}
+
+// SpecFields returns the fields nested under the "spec" field of the definition
+func (d *Definition) SpecFields() Fields {
+	return d.nestedFields("spec")
+}
+
+// StatusFields returns the fields nested under the "status" field of the definition
+func (d *Definition) StatusFields() Fields {
+	return d.nestedFields("status")
+}
+
+// nestedFields returns the fields of the definition referenced by the named field.  Returns nil
+// if there is no such field or it is not a complex type.
+func (d *Definition) nestedFields(name string) Fields {
+	if field, found := d.GetField(name); found && field.Definition != nil {
+		return field.Definition.Fields
+	}
+	return nil
+}
+
+// GetField looks up a field of the definition by name
+func (d *Definition) GetField(name string) (*Field, bool) {
+	for _, field := range d.Fields {
+		if field.Name == name {
+			return field, true
+		}
 	}
-	return fmt.Sprintf("<a href=\"#%s-%s-%s\">%s</a>", strings.ToLower(d.Name), d.Version, d.Group, d.Version)
+	return nil, false
 }
 
 func (d Definition) Description() string {
 	return d.schema.Description
 }
 
+// ShortDescription returns the first sentence of the description, shortened to at most maxLen characters
+func (d Definition) ShortDescription(maxLen int) string {
+	return shortDescription(d.Description(), maxLen)
+}
+
 func VisitDefinitions(specs []*loads.Document, fn func(definition *Definition)) {
-	groups := map[string]string{}
+	errs, _ := visitDefinitions(context.Background(), specs, fn)
+	for _, err := range errs {
+		fmt.Printf("Error: %v.\n", err)
+	}
+}
+
+// hasVersionAndType returns true if the dot separated parts of a definition name can hold a version and type.
+// With --short-definition-names, three part names are accepted if the middle part is a version.
+func hasVersionAndType(parts []string) bool {
+	if *ShortDefinitionNames && len(parts) == 3 {
+		return shortNameVersion.MatchString(parts[1])
+	}
+	return len(parts) >= 4
+}
+
+// visitDefinitions calls fn once for each definition found in the collection of Documents and returns
+// the definitions that could not be parsed.  Returns ctx.Err() if ctx is done before all definitions are visited.
+func visitDefinitions(ctx context.Context, specs []*loads.Document, fn func(definition *Definition)) ([]error, error) {
+	errs := []error{}
+	done, total := 0, 0
+	for _, spec := range specs {
+		total += len(spec.Spec().Definitions)
+	}
 	for _, spec := range specs {
 		for name, spec := range spec.Spec().Definitions {
+			if err := ctx.Err(); err != nil {
+				return errs, err
+			}
+			done++
+			reportProgress("parsing", done, total)
+
 			resource := ""
-			if r, found := spec.Extensions.GetString(resourceNameKey); found {
+			if r, found := spec.Extensions.GetString(*ResourceNameKey); found {
 				resource = r
 			}
 
 			parts := strings.Split(name, ".")
-			if len(parts) < 4 {
-				fmt.Printf("Error: Could not find version and type for definition %s.\n", name)
+			if !hasVersionAndType(parts) {
+				errs = append(errs, fmt.Errorf("Could not find version and type for definition %s", name))
 				continue
 			}
-			var group, version, kind string
-			if parts[len(parts)-3] == "api" {
-				// e.g. "io.k8s.kubernetes.pkg.api.v1.Pod"
-				group = "core"
-				version = parts[len(parts)-2]
-				kind = parts[len(parts)-1]
-				groups[group] = ""
-			} else if parts[len(parts)-4] == "apis" {
-				// e.g. "io.k8s.kubernetes.pkg.apis.extensions.v1beta1.Deployment"
-				group = parts[len(parts)-3]
-				version = parts[len(parts)-2]
-				kind = parts[len(parts)-1]
-				groups[group] = ""
-			} else if parts[len(parts)-3] == "util" || parts[len(parts)-3] == "pkg" {
-				// e.g. io.k8s.apimachinery.pkg.util.intstr.IntOrString
-				// e.g. io.k8s.apimachinery.pkg.runtime.RawExtension
+			group, version, kind, err := parseDefinitionName(name)
+			if err != nil {
+				panic(err)
+			}
+			if len(kind) == 0 {
 				continue
-			} else {
-				panic(errors.New(fmt.Sprintf("Could not locate group for %s", name)))
+			}
+
+			inferred := ""
+			if len(resource) == 0 {
+				inferred = strings.ToLower(Pluralize(kind))
+			}
+			var namespaced *bool
+			if scope, found := spec.Extensions.GetString(*ScopeKey); found && (scope == "Namespaced" || scope == "Cluster") {
+				namespaced = new(bool)
+				*namespaced = scope == "Namespaced"
+			} else if n, found := NamespacedKinds[kind]; found {
+				namespaced = &n
 			}
 
 			fn(&Definition{
-				schema:    spec,
-				Name:      kind,
-				Version:   ApiVersion(version),
-				Kind:      ApiKind(kind),
-				Group:     ApiGroup(group),
-				ShowGroup: !*UseTags,
-				Resource:  resource,
+				schema:           spec,
+				FullName:         name,
+				Name:             kind,
+				Version:          ApiVersion(version),
+				Kind:             ApiKind(kind),
+				Group:            ApiGroup(group),
+				ShowGroup:        !*UseTags,
+				Resource:         resource,
+				InferredResource: inferred,
+				Namespaced:       namespaced,
 			})
 		}
 	}
+	return errs, nil
 }
 
 func (d *Definition) GetSamples() []ExampleText {
@@ -263,15 +694,87 @@ func (d *Definition) GetSamples() []ExampleText {
 	return r
 }
 
+// HasSamples returns true if any example provider returns a non-empty sample for the definition
+func (d *Definition) HasSamples() bool {
+	for _, sample := range d.GetSamples() {
+		if len(strings.TrimSpace(sample.Text)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func GetDefinitions(specs []*loads.Document) Definitions {
-	d := Definitions{
+	d, err := GetDefinitionsContext(context.Background(), specs)
+	if err != nil {
+		if !*AllowErrors {
+			panic(err)
+		}
+		os.Stderr.WriteString(fmt.Sprintf("Error: %v.\n", err))
+	}
+	return d
+}
+
+// GetDefinitionsContext builds the Definitions for specs, returning ctx.Err() if ctx is done before
+// the build completes.  If --fail-on-unresolved is set, returns an error listing the fields referencing
+// definitions that are not found.
+func GetDefinitionsContext(ctx context.Context, specs []*loads.Document) (Definitions, error) {
+	d := NewDefinitions()
+	errs, err := d.load(ctx, specs)
+	for _, err := range errs {
+		fmt.Printf("Error: %v.\n", err)
+	}
+	if err != nil {
+		return Definitions{}, err
+	}
+	if err := d.rebuildRelationships(ctx); err != nil {
+		return Definitions{}, err
+	}
+	if *FailOnUnresolved {
+		if err := d.unresolvedFieldsError(); err != nil {
+			return *d, err
+		}
+	}
+	return *d, nil
+}
+
+// NewDefinitions returns an empty set of Definitions
+func NewDefinitions() *Definitions {
+	return &Definitions{
 		ByGroupVersionKind: map[string]*Definition{},
 		ByKind:             map[string]SortDefinitionsByVersion{},
 	}
-	VisitDefinitions(specs, func(definition *Definition) {
+}
+
+// Load adds the definitions found in specs.  Load may be called multiple times and additional definitions
+// may be added with Put.  Fields and relationships between definitions are computed by RebuildRelationships.
+func (d *Definitions) Load(specs []*loads.Document) []error {
+	errs, _ := d.load(context.Background(), specs)
+	return errs
+}
+
+func (d *Definitions) load(ctx context.Context, specs []*loads.Document) ([]error, error) {
+	return visitDefinitions(ctx, specs, func(definition *Definition) {
 		d.Put(definition)
 	})
-	d.InitializeFieldsForAll()
+}
+
+// RebuildRelationships reinitializes the fields of all definitions and recomputes the versions, "Appears In",
+// inlined and replacement relationships between them.  Fields are rebuilt so that fields referencing
+// definitions added since the last rebuild are resolved.
+func (d *Definitions) RebuildRelationships() {
+	d.rebuildRelationships(context.Background())
+}
+
+func (d *Definitions) rebuildRelationships(ctx context.Context) error {
+	d.searchIndex = nil
+	d.ByKind = map[string]SortDefinitionsByVersion{}
+	for _, def := range d.GetAllDefinitions() {
+		def.resetRelationships()
+	}
+	if err := d.initializeFieldsForAll(ctx); err != nil {
+		return err
+	}
 	for _, def := range d.GetAllDefinitions() {
 		d.ByKind[def.Name] = append(d.ByKind[def.Name], def)
 	}
@@ -282,7 +785,7 @@ func GetDefinitions(specs []*loads.Document) Definitions {
 		if len(l) <= 1 {
 			continue
 		}
-		sort.Sort(l)
+		sort.Stable(l)
 		// Mark all version as old
 		for i, d := range l {
 			if i > 0 {
@@ -290,8 +793,29 @@ func GetDefinitions(specs []*loads.Document) Definitions {
 			}
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	d.InitializeOtherVersions()
+	reportProgress("relationships", 1, 4)
 	d.initAppearsIn()
+	reportProgress("relationships", 2, 4)
 	d.initInlinedDefinitions()
-	return d
+	reportProgress("relationships", 3, 4)
+	d.initReplacedBy()
+	reportProgress("relationships", 4, 4)
+	return nil
+}
+
+// resetRelationships clears the state computed from other definitions
+func (d *Definition) resetRelationships() {
+	d.Fields = nil
+	d.ShadowedFields = nil
+	d.IsOldVersion = false
+	d.IsInlined = false
+	d.FoundInField = false
+	d.Inline = nil
+	d.AppearsIn = nil
+	d.OtherVersions = nil
+	d.ReplacedBy = nil
 }
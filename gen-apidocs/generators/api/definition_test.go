@@ -0,0 +1,579 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+)
+
+// setProgress sets the Progress hook for the duration of the test
+func setProgress(t *testing.T, fn ProgressFunc) {
+	saved := Progress
+	Progress = fn
+	t.Cleanup(func() { Progress = saved })
+}
+
+func TestGetDefinitionsContextCancelledWhileParsing(t *testing.T) {
+	definitions := schema{}
+	for i := 0; i < 40; i++ {
+		definitions[fmt.Sprintf("io.k8s.api.core.v1.Kind%d", i)] = object(schema{"name": str()})
+	}
+	doc := newSpec(t, definitions)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	parsed, stages := 0, map[string]bool{}
+	setProgress(t, func(stage string, done, total int) {
+		stages[stage] = true
+		if stage == "parsing" {
+			parsed = done
+			cancel()
+		}
+	})
+
+	if _, err := GetDefinitionsContext(ctx, []*loads.Document{doc}); err != context.Canceled {
+		t.Fatalf("GetDefinitionsContext() error = %v, want %v", err, context.Canceled)
+	}
+	if parsed == 40 {
+		t.Errorf("parsed all definitions, want parsing to stop when cancelled")
+	}
+	if stages["fields"] || stages["relationships"] {
+		t.Errorf("stages %v reported, want only parsing", stages)
+	}
+}
+
+func TestGetDefinitionsContextNotCancelled(t *testing.T) {
+	doc := newSpec(t, schema{"io.k8s.api.core.v1.Pod": object(schema{"name": str()})})
+	d, err := GetDefinitionsContext(context.Background(), []*loads.Document{doc})
+	if err != nil {
+		t.Fatalf("GetDefinitionsContext() error = %v", err)
+	}
+	mustGet(t, &d, "core.v1.Pod")
+}
+
+// newTocDefinitions returns definitions with a Pod inlining its PodSpec and two versions of Deployment, with
+// every definition marked as in the table of contents
+func newTocDefinitions(t *testing.T) Definitions {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":             object(schema{"spec": ref("io.k8s.api.core.v1.PodSpec")}),
+		"io.k8s.api.core.v1.PodSpec":         object(schema{"hostname": str()}),
+		"io.k8s.api.apps.v1.Deployment":      object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.ObjectReference": object(schema{"name": str()}),
+	})
+	for _, definition := range d.GetAllDefinitions() {
+		definition.InToc = definition.Name != "ObjectReference"
+	}
+	return d
+}
+
+func TestInitializeFieldsForAllReinitializes(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{"kind": str()}),
+	})
+	pod := mustGet(t, &d, "core.v1.Pod")
+	pod.schema.Properties["spec"] = *spec.StringProperty()
+	d.InitializeFieldsForAll()
+	if got, expected := fieldNames(pod.Fields), []string{"kind", "spec"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields after edit = %v, want %v", got, expected)
+	}
+}
+
+func TestTocDefinitionsExcludesInlinedAndOldVersions(t *testing.T) {
+	d := newTocDefinitions(t)
+	got := []string{}
+	for _, definition := range d.TocDefinitions() {
+		got = append(got, definition.Key())
+	}
+	expected := []string{"apps.v1.Deployment", "core.v1.Pod"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("TocDefinitions() = %v, want %v", got, expected)
+	}
+	if mustGet(t, &d, "core.v1.PodSpec").IsInToc() {
+		t.Errorf("inlined PodSpec IsInToc() = true, want false")
+	}
+	if mustGet(t, &d, "apps.v1beta1.Deployment").IsInToc() {
+		t.Errorf("old version Deployment IsInToc() = true, want false")
+	}
+}
+
+func TestStandaloneDefinitions(t *testing.T) {
+	d := newTocDefinitions(t)
+	got := []string{}
+	for _, definition := range d.StandaloneDefinitions() {
+		got = append(got, definition.Key())
+	}
+	expected := []string{"core.v1.ObjectReference"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("StandaloneDefinitions() = %v, want %v", got, expected)
+	}
+}
+
+func TestSpecAndStatusFields(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"kind":   str(),
+			"spec":   ref("io.k8s.api.core.v1.PodSpec"),
+			"status": ref("io.k8s.api.core.v1.PodStatus"),
+		}),
+		"io.k8s.api.core.v1.PodSpec":   object(schema{"hostname": str(), "nodeName": str()}),
+		"io.k8s.api.core.v1.PodStatus": object(schema{"phase": str()}),
+	})
+	pod := mustGet(t, &d, "core.v1.Pod")
+	if got, expected := fieldNames(pod.SpecFields()), []string{"hostname", "nodeName"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("SpecFields() = %v, want %v", got, expected)
+	}
+	if got, expected := fieldNames(pod.StatusFields()), []string{"phase"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("StatusFields() = %v, want %v", got, expected)
+	}
+
+	spec := mustGet(t, &d, "core.v1.PodSpec")
+	if fields := spec.SpecFields(); fields != nil {
+		t.Errorf("SpecFields() of a definition without a spec = %v, want nil", fieldNames(fields))
+	}
+}
+
+func TestRebuildRelationshipsResolvesPutDefinitions(t *testing.T) {
+	d := NewDefinitions()
+	if errs := d.Load([]*loads.Document{newSpec(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{"spec": ref("io.k8s.api.core.v1.PodSpec")}),
+	})}); len(errs) > 0 {
+		t.Fatalf("Load() = %v", errs)
+	}
+	d.RebuildRelationships()
+	pod := mustGet(t, d, "core.v1.Pod")
+	if field, _ := pod.GetField("spec"); field == nil || field.Definition != nil {
+		t.Fatalf("spec field before Put = %+v, want an unresolved field", field)
+	}
+
+	VisitDefinitions([]*loads.Document{newSpec(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{"hostname": str()}),
+	})}, func(definition *Definition) {
+		d.Put(definition)
+	})
+	d.RebuildRelationships()
+
+	podSpec := mustGet(t, d, "core.v1.PodSpec")
+	field, _ := pod.GetField("spec")
+	if field == nil || field.Definition != podSpec {
+		t.Errorf("spec field after Put = %+v, want a field referencing PodSpec", field)
+	}
+	if len(pod.Fields) != 1 {
+		t.Errorf("Pod fields = %v, want [spec]", fieldNames(pod.Fields))
+	}
+	if len(podSpec.AppearsIn) != 1 || podSpec.AppearsIn[0] != pod {
+		t.Errorf("PodSpec AppearsIn = %v, want [Pod]", podSpec.AppearsIn)
+	}
+	if len(d.UnresolvedFields()) != 0 {
+		t.Errorf("UnresolvedFields() = %v, want none", d.UnresolvedFields())
+	}
+}
+
+func TestInheritDescriptions(t *testing.T) {
+	setFlag(t, "inherit-descriptions", "true")
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"spec":   ref("io.k8s.api.core.v1.PodSpec"),
+			"status": schema{"$ref": "#/definitions/io.k8s.api.core.v1.PodStatus", "description": "Most recently observed status."},
+		}),
+		"io.k8s.api.core.v1.PodSpec":   schema{"type": "object", "description": "PodSpec is a description\nof a pod."},
+		"io.k8s.api.core.v1.PodStatus": schema{"type": "object", "description": "PodStatus is the status of a pod."},
+	})
+	pod := mustGet(t, &d, "core.v1.Pod")
+	if field, _ := pod.GetField("spec"); field.Description != "PodSpec is a description of a pod." {
+		t.Errorf("spec Description = %q, want the PodSpec description", field.Description)
+	}
+	if field, _ := pod.GetField("status"); field.Description != "Most recently observed status." {
+		t.Errorf("status Description = %q, want its own description", field.Description)
+	}
+}
+
+func TestIsOldVersionStableAcrossGroups(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		d := newDefinitions(t, schema{
+			"io.k8s.api.extensions.v1beta1.Deployment": object(schema{"kind": str()}),
+			"io.k8s.api.apps.v1beta1.Deployment":       object(schema{"kind": str()}),
+		})
+		if mustGet(t, &d, "apps.v1beta1.Deployment").IsOldVersion {
+			t.Fatalf("run %d: apps.v1beta1.Deployment IsOldVersion = true, want false", i)
+		}
+		if !mustGet(t, &d, "extensions.v1beta1.Deployment").IsOldVersion {
+			t.Fatalf("run %d: extensions.v1beta1.Deployment IsOldVersion = false, want true", i)
+		}
+	}
+}
+
+func TestInitializeFieldsAllOf(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": schema{
+			"type": "object",
+			"allOf": []schema{
+				ref("io.k8s.api.apps.v1.Workload"),
+				object(schema{"paused": str()}),
+			},
+			"properties": schema{"replicas": describedStr("Number of desired pods.")},
+		},
+		"io.k8s.api.apps.v1.Workload": schema{
+			"type":       "object",
+			"allOf":      []schema{ref("io.k8s.api.apps.v1.Object")},
+			"properties": schema{"replicas": describedStr("Number of replicas."), "selector": str()},
+		},
+		"io.k8s.api.apps.v1.Object": object(schema{"kind": str(), "selector": describedStr("Base selector.")}),
+	})
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	if got, expected := fieldNames(deployment.Fields), []string{"kind", "paused", "replicas", "selector"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields = %v, want %v", got, expected)
+	}
+	if field, _ := deployment.GetField("replicas"); field.Description != "Number of desired pods." {
+		t.Errorf("replicas Description = %q, want the most derived declaration", field.Description)
+	}
+	if field, _ := deployment.GetField("selector"); field.Description != "" {
+		t.Errorf("selector Description = %q, want the Workload declaration", field.Description)
+	}
+	if got, expected := fieldNames(deployment.ShadowedFields), []string{"replicas", "selector"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("ShadowedFields = %v, want %v", got, expected)
+	}
+}
+
+func TestGroupFullName(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":        object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.rbac.v1.Role":       object(schema{"kind": str()}),
+		"io.k8s.api.acme.v1.Widget":     object(schema{"kind": str()}),
+	})
+	tests := map[string]string{
+		"core.v1.Pod":        "",
+		"apps.v1.Deployment": "apps",
+		"rbac.v1.Role":       "rbac.authorization.k8s.io",
+		"acme.v1.Widget":     "acme",
+	}
+	for key, expected := range tests {
+		if got := mustGet(t, &d, key).GroupFullName(); got != expected {
+			t.Errorf("%s GroupFullName() = %q, want %q", key, got, expected)
+		}
+	}
+}
+
+func TestCurrentVersionsOnly(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment":      object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta2.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.batch.v1.CronJob":        object(schema{"kind": str()}),
+		"io.k8s.api.batch.v1beta1.CronJob":   object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.Pod":             object(schema{"kind": str()}),
+		"io.k8s.api.autoscaling.v1.Scale":    object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1.Scale":           object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Scale":      object(schema{"kind": str()}),
+	})
+	got := []string{}
+	for _, definition := range d.CurrentVersionsOnly() {
+		got = append(got, definition.Key())
+	}
+	expected := []string{"batch.v1.CronJob", "apps.v1.Deployment", "core.v1.Pod", "apps.v1.Scale"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("CurrentVersionsOnly() = %v, want %v", got, expected)
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	definitions := schema{}
+	for i := 0; i < 45; i++ {
+		definitions[fmt.Sprintf("io.k8s.api.core.v1.Kind%d", i)] = object(schema{"name": str()})
+	}
+	doc := newSpec(t, definitions)
+
+	done := map[string][]int{}
+	setProgress(t, func(stage string, d, total int) {
+		if stage != "relationships" && total != 45 {
+			t.Errorf("%s total = %d, want 45", stage, total)
+		}
+		done[stage] = append(done[stage], d)
+	})
+	GetDefinitions([]*loads.Document{doc})
+
+	for _, stage := range []string{"parsing", "fields", "relationships"} {
+		counts := done[stage]
+		if len(counts) < 2 {
+			t.Errorf("%s reported %v, want several updates", stage, counts)
+			continue
+		}
+		for i := 1; i < len(counts); i++ {
+			if counts[i] <= counts[i-1] {
+				t.Errorf("%s reported %v, want increasing counts", stage, counts)
+				break
+			}
+		}
+		if last := counts[len(counts)-1]; stage != "relationships" && last != 45 {
+			t.Errorf("%s last reported %d, want 45", stage, last)
+		}
+	}
+	if len(done["parsing"]) > 25 {
+		t.Errorf("parsing reported %d times, want about 20", len(done["parsing"]))
+	}
+}
+
+func TestResourceKey(t *testing.T) {
+	setFlag(t, "resource-key", "x-acme-resource")
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment":          schema{"type": "object", "x-acme-resource": "deploys"},
+		"io.k8s.api.apps.v1.StatefulSet":         schema{"type": "object", "x-kubernetes-resource": "sets"},
+		"io.k8s.api.core.v1.Endpoints":           schema{"type": "object"},
+		"io.k8s.api.networking.v1.NetworkPolicy": schema{"type": "object"},
+	})
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	if deployment.Resource != "deploys" || deployment.InferredResource != "" {
+		t.Errorf("Deployment Resource = %q, InferredResource = %q, want deploys from the custom extension",
+			deployment.Resource, deployment.InferredResource)
+	}
+	statefulSet := mustGet(t, &d, "apps.v1.StatefulSet")
+	if statefulSet.Resource != "" || statefulSet.InferredResource != "statefulsets" {
+		t.Errorf("StatefulSet Resource = %q, InferredResource = %q, want an inferred resource",
+			statefulSet.Resource, statefulSet.InferredResource)
+	}
+	inferred := map[string]string{"core.v1.Endpoints": "endpoints", "networking.v1.NetworkPolicy": "networkpolicies"}
+	for key, expected := range inferred {
+		if got := mustGet(t, &d, key).InferredResource; got != expected {
+			t.Errorf("%s InferredResource = %q, want %q", key, got, expected)
+		}
+	}
+}
+
+func TestNamespaced(t *testing.T) {
+	saved := NamespacedKinds
+	NamespacedKinds = map[string]bool{"Role": true}
+	t.Cleanup(func() { NamespacedKinds = saved })
+
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":     schema{"type": "object", "x-kubernetes-scope": "Namespaced"},
+		"io.k8s.api.core.v1.Node":    schema{"type": "object", "x-kubernetes-scope": "Cluster"},
+		"io.k8s.api.rbac.v1.Role":    object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.PodSpec": object(schema{"hostname": str()}),
+		"io.k8s.api.core.v1.Binding": schema{"type": "object", "x-kubernetes-scope": "Unknown"},
+	})
+	tests := map[string]string{
+		"core.v1.Pod":     "Yes",
+		"core.v1.Node":    "No",
+		"rbac.v1.Role":    "Yes",
+		"core.v1.PodSpec": "",
+		"core.v1.Binding": "",
+	}
+	for key, expected := range tests {
+		definition := mustGet(t, &d, key)
+		if got := definition.NamespacedDisplay(); got != expected {
+			t.Errorf("%s NamespacedDisplay() = %q, want %q", key, got, expected)
+		}
+		if (definition.Namespaced == nil) != (expected == "") {
+			t.Errorf("%s Namespaced = %v, want known %v", key, definition.Namespaced, expected != "")
+		}
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	d := newDefinitions(t, schema{"io.k8s.api.core.v1.Pod": object(schema{"kind": str()})})
+	pod := mustGet(t, &d, "core.v1.Pod")
+	tests := []struct {
+		ref   string
+		found bool
+	}{
+		{"#/definitions/io.k8s.api.core.v1.Pod", true},
+		{"#/components/schemas/io.k8s.api.core.v1.Pod", true},
+		{"#/definitions/io.k8s.api.core.v1.Missing", false},
+		{"#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.IntOrString", false},
+	}
+	for _, test := range tests {
+		got, found := d.ResolveRef(spec.MustCreateRef(test.ref))
+		if found != test.found || (found && got != pod) {
+			t.Errorf("ResolveRef(%s) = %v, %v, want found %v", test.ref, got, found, test.found)
+		}
+	}
+}
+
+func TestAnchorPrefix(t *testing.T) {
+	setFlag(t, "anchor-prefix", "api-")
+	d := &Definition{Name: "Deployment", Group: "apps", Version: "v1", Kind: "Deployment"}
+	got := map[string]string{
+		"MdLink":      d.MdLink(),
+		"HrefLink":    d.HrefLink(),
+		"VersionLink": d.VersionLink(),
+	}
+	expected := map[string]string{
+		"MdLink":      "[Deployment](#api-deployment-v1-apps)",
+		"HrefLink":    `<a href="#api-deployment-v1-apps">Deployment</a>`,
+		"VersionLink": `<a href="#api-deployment-v1-apps">v1</a>`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("links = %v, want %v", got, expected)
+	}
+	if got := d.AnchorPrefix(); got != "api-" {
+		t.Errorf("AnchorPrefix() = %q, want api-", got)
+	}
+}
+
+func TestMissingSamples(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":        object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1.Deployment": object(schema{"kind": str()}),
+	})
+	for _, definition := range d.GetAllDefinitions() {
+		definition.InToc = true
+	}
+	mustGet(t, &d, "core.v1.Pod").Sample.Sample = "kind: Pod\n"
+
+	setFlag(t, "build-operations", "false")
+	if got, expected := keys(d.MissingSamples()), []string{"apps.v1.Deployment"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("MissingSamples() = %v, want %v", got, expected)
+	}
+
+	saved := ExampleProviders
+	ExampleProviders = []ExampleProvider{AutoExampleProvider()}
+	t.Cleanup(func() { ExampleProviders = saved })
+	setFlag(t, "build-operations", "true")
+	if got := keys(d.MissingSamples()); len(got) != 0 {
+		t.Errorf("MissingSamples() with a generating provider = %v, want none", got)
+	}
+}
+
+func TestInitializeAdditional(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Labels": schema{
+			"type":                 "object",
+			"properties":           schema{"name": str()},
+			"additionalProperties": true,
+		},
+		"io.k8s.api.core.v1.ResourceList": schema{
+			"type":                 "object",
+			"properties":           schema{"name": str()},
+			"additionalProperties": ref("io.k8s.api.core.v1.Quantity"),
+		},
+		"io.k8s.api.core.v1.Strings": schema{
+			"type":                 "object",
+			"properties":           schema{"name": str()},
+			"additionalProperties": str(),
+		},
+		"io.k8s.api.core.v1.Closed": schema{
+			"type":                 "object",
+			"properties":           schema{"name": str()},
+			"additionalProperties": false,
+		},
+		"io.k8s.api.core.v1.Quantity": object(schema{"value": str()}),
+	})
+	quantity := mustGet(t, &d, "core.v1.Quantity")
+	tests := []struct {
+		key        string
+		allows     bool
+		typ        string
+		definition *Definition
+	}{
+		{"core.v1.Labels", true, "any", nil},
+		{"core.v1.ResourceList", true, "Quantity", quantity},
+		{"core.v1.Strings", true, "string", nil},
+		{"core.v1.Closed", false, "", nil},
+	}
+	for _, test := range tests {
+		definition := mustGet(t, &d, test.key)
+		if definition.AllowsAdditional != test.allows || definition.AdditionalType != test.typ || definition.AdditionalDefinition != test.definition {
+			t.Errorf("%s additional = %v, %q, %v, want %v, %q, %v", test.key,
+				definition.AllowsAdditional, definition.AdditionalType, definition.AdditionalDefinition,
+				test.allows, test.typ, test.definition)
+		}
+	}
+}
+
+func TestShortDefinitionNames(t *testing.T) {
+	setFlag(t, "short-definition-names", "true")
+	d := NewDefinitions()
+	errs := d.Load([]*loads.Document{newSpec(t, schema{
+		"acme.v1.Thing":          object(schema{"size": str()}),
+		"acme.latest.Gadget":     object(schema{"size": str()}),
+		"io.k8s.api.core.v1.Pod": object(schema{"kind": str()}),
+	})})
+	d.RebuildRelationships()
+
+	if got, expected := keys(d.CurrentVersionsOnly()), []string{"core.v1.Pod", "acme.v1.Thing"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("definitions = %v, want %v", got, expected)
+	}
+	if len(errs) != 1 || errs[0].Error() != "Could not find version and type for definition acme.latest.Gadget" {
+		t.Errorf("Load() errors = %v, want an error for acme.latest.Gadget", errs)
+	}
+}
+
+func TestSortFieldsPropertyOrder(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": schema{
+			"type": "object",
+			"properties": schema{
+				"apiVersion": str(),
+				"kind":       str(),
+				"metadata":   str(),
+				"spec":       str(),
+				"status":     str(),
+				"extra":      str(),
+			},
+			"x-kubernetes-property-order": []string{"kind", "apiVersion", "spec", "missing"},
+		},
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{"replicas": str(), "paused": str()}),
+	})
+	expected := []string{"kind", "apiVersion", "spec", "extra", "metadata", "status"}
+	if got := fieldNames(mustGet(t, &d, "apps.v1.Deployment").Fields); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields = %v, want %v", got, expected)
+	}
+	if got, expected := fieldNames(mustGet(t, &d, "apps.v1.DeploymentSpec").Fields), []string{"paused", "replicas"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields without a property order = %v, want %v", got, expected)
+	}
+}
+
+func TestNilExtensions(t *testing.T) {
+	doc := newSpec(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"hostname":     str(),
+			"containers":   schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+			"nodeSelector": schema{"type": "object", "additionalProperties": str()},
+		}),
+		"io.k8s.api.core.v1.Container": object(schema{"name": str()}),
+	})
+	for name, s := range doc.Spec().Definitions {
+		if s.Extensions != nil {
+			t.Fatalf("%s Extensions = %v, want nil", name, s.Extensions)
+		}
+	}
+
+	visited := 0
+	VisitDefinitions([]*loads.Document{doc}, func(definition *Definition) {
+		visited++
+		if definition.Resource != "" || definition.Namespaced != nil {
+			t.Errorf("%s Resource = %q, Namespaced = %v, want neither", definition.Name, definition.Resource, definition.Namespaced)
+		}
+	})
+	if visited != 2 {
+		t.Errorf("VisitDefinitions() visited %d definitions, want 2", visited)
+	}
+
+	d := GetDefinitions([]*loads.Document{doc})
+	for _, field := range mustGet(t, &d, "core.v1.PodSpec").Fields {
+		if field.PatchStrategy != "" || field.PatchMergeKey != "" || field.Immutable || len(field.EnumDescriptions) != 0 {
+			t.Errorf("%s read extension values %+v, want none", field.Name, field)
+		}
+	}
+}
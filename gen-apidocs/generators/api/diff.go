@@ -0,0 +1,96 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import "sort"
+
+// FieldDiff is the set of field changes between two definitions
+type FieldDiff struct {
+	// Added are fields only present in the other definition
+	Added Fields
+	// Removed are fields only present in this definition
+	Removed Fields
+	// Modified are fields present in both definitions that differ
+	Modified FieldChanges
+}
+
+// FieldChange describes how a field differs between two definitions
+type FieldChange struct {
+	Name string
+	Old  *Field
+	New  *Field
+
+	TypeChanged        bool
+	RequiredChanged    bool
+	DescriptionChanged bool
+	DeprecationChanged bool
+}
+
+type FieldChanges []*FieldChange
+
+func (a FieldChanges) Len() int           { return len(a) }
+func (a FieldChanges) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a FieldChanges) Less(i, j int) bool { return a[i].Name < a[j].Name }
+
+// IsEmpty returns true if the definitions have identical fields
+func (fd FieldDiff) IsEmpty() bool {
+	return len(fd.Added) == 0 && len(fd.Removed) == 0 && len(fd.Modified) == 0
+}
+
+// DiffFields returns the fields added, removed and modified going from this definition to other.
+// Fields are matched by Name.
+func (d *Definition) DiffFields(other *Definition) FieldDiff {
+	diff := FieldDiff{}
+	theirs := map[string]*Field{}
+	for _, f := range other.Fields {
+		theirs[f.Name] = f
+	}
+	ours := map[string]*Field{}
+	for _, f := range d.Fields {
+		ours[f.Name] = f
+	}
+
+	for name, before := range ours {
+		after, found := theirs[name]
+		if !found {
+			diff.Removed = append(diff.Removed, before)
+			continue
+		}
+		change := &FieldChange{
+			Name:               name,
+			Old:                before,
+			New:                after,
+			TypeChanged:        before.Type != after.Type,
+			RequiredChanged:    before.Required != after.Required,
+			DescriptionChanged: before.Description != after.Description,
+			DeprecationChanged: before.Deprecated != after.Deprecated,
+		}
+		if change.TypeChanged || change.RequiredChanged || change.DescriptionChanged || change.DeprecationChanged {
+			diff.Modified = append(diff.Modified, change)
+		}
+	}
+	for name, after := range theirs {
+		if _, found := ours[name]; !found {
+			diff.Added = append(diff.Added, after)
+		}
+	}
+
+	sort.Sort(diff.Added)
+	sort.Sort(diff.Removed)
+	sort.Sort(diff.Modified)
+	return diff
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1beta1.Deployment": schema{
+			"type":     "object",
+			"required": []string{"replicas"},
+			"properties": schema{
+				"paused":          describedStr("Indicates that the deployment is paused."),
+				"replicas":        describedStr("Number of desired pods."),
+				"rollbackTo":      describedStr("The config this deployment is rolling back to."),
+				"revisionHistory": describedStr("The number of old ReplicaSets to retain."),
+				"selector":        describedStr("Label selector for pods."),
+				"template":        describedStr("Template describes the pods that will be created."),
+			},
+		},
+		"io.k8s.api.apps.v1.Deployment": schema{
+			"type": "object",
+			"properties": schema{
+				"minReadySeconds": describedStr("Minimum number of seconds for which a newly created pod should be ready."),
+				"paused":          describedStr("Indicates that the deployment is paused."),
+				"replicas":        describedStr("Number of desired pods."),
+				"revisionHistory": describedStr("DEPRECATED. The number of old ReplicaSets to retain."),
+				"selector":        schema{"type": "integer", "description": "Label selector for pods."},
+				"template":        describedStr("Template describes the pods that will be created and run."),
+			},
+		},
+	})
+	diff := mustGet(t, &d, "apps.v1beta1.Deployment").DiffFields(mustGet(t, &d, "apps.v1.Deployment"))
+
+	if got, expected := fieldNames(diff.Added), []string{"minReadySeconds"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Added = %v, want %v", got, expected)
+	}
+	if got, expected := fieldNames(diff.Removed), []string{"rollbackTo"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Removed = %v, want %v", got, expected)
+	}
+
+	expected := []FieldChange{
+		{Name: "replicas", RequiredChanged: true},
+		{Name: "revisionHistory", DescriptionChanged: true, DeprecationChanged: true},
+		{Name: "selector", TypeChanged: true},
+		{Name: "template", DescriptionChanged: true},
+	}
+	if len(diff.Modified) != len(expected) {
+		t.Fatalf("Modified = %d changes, want %d", len(diff.Modified), len(expected))
+	}
+	for i, change := range diff.Modified {
+		got := FieldChange{
+			Name:               change.Name,
+			TypeChanged:        change.TypeChanged,
+			RequiredChanged:    change.RequiredChanged,
+			DescriptionChanged: change.DescriptionChanged,
+			DeprecationChanged: change.DeprecationChanged,
+		}
+		if got != expected[i] {
+			t.Errorf("Modified[%d] = %+v, want %+v", i, got, expected[i])
+		}
+		if change.Old == nil || change.New == nil {
+			t.Errorf("Modified[%d] missing the old or new field", i)
+		}
+	}
+}
+
+func TestDiffFieldsIdentical(t *testing.T) {
+	fields := schema{"replicas": describedStr("Number of desired pods.")}
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1beta1.Deployment": object(fields),
+		"io.k8s.api.apps.v1.Deployment":      object(fields),
+	})
+	diff := mustGet(t, &d, "apps.v1beta1.Deployment").DiffFields(mustGet(t, &d, "apps.v1.Deployment"))
+	if !diff.IsEmpty() {
+		t.Errorf("DiffFields() of identical definitions = %+v, want empty", diff)
+	}
+}
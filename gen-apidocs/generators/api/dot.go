@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// DOTOptions control the graph written by WriteDOT
+type DOTOptions struct {
+	// Group limits the graph to definitions in the api group when set e.g. "apps"
+	Group string
+	// CollapseInlined attributes the references of inlined definitions to the definitions they are inlined
+	// into instead of writing them as separate nodes
+	CollapseInlined bool
+}
+
+type dotEdge struct {
+	from, to, label string
+	inline          bool
+}
+
+type dotEdges []dotEdge
+
+func (a dotEdges) Len() int      { return len(a) }
+func (a dotEdges) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a dotEdges) Less(i, j int) bool {
+	if a[i].from != a[j].from {
+		return a[i].from < a[j].from
+	}
+	if a[i].to != a[j].to {
+		return a[i].to < a[j].to
+	}
+	return a[i].label < a[j].label
+}
+
+// WriteDOT writes a graphviz graph with a node for each definition in TocDefinitions and an edge for each
+// field referencing another definition.  Edges to inlined definitions are dashed.
+func (d *Definitions) WriteDOT(w io.Writer, opts DOTOptions) error {
+	inGroup := func(definition *Definition) bool {
+		return len(opts.Group) == 0 || definition.Group.String() == opts.Group
+	}
+
+	nodes := map[string]*Definition{}
+	edges := dotEdges{}
+	pending := []*Definition{}
+	for _, definition := range d.TocDefinitions() {
+		if inGroup(definition) {
+			nodes[definition.Key()] = definition
+			pending = append(pending, definition)
+		}
+	}
+	for len(pending) > 0 {
+		from := pending[0]
+		pending = pending[1:]
+		for _, ref := range dotReferences(from, "", opts.CollapseInlined, maxInlineDepth) {
+			to := ref.definition
+			if !inGroup(to) {
+				continue
+			}
+			edges = append(edges, dotEdge{from: from.Key(), to: to.Key(), label: ref.field, inline: to.IsInlined})
+			if _, found := nodes[to.Key()]; !found {
+				nodes[to.Key()] = to
+				if to.IsInlined {
+					pending = append(pending, to)
+				}
+			}
+		}
+	}
+
+	keys := []string{}
+	for k := range nodes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sort.Sort(edges)
+
+	if _, err := fmt.Fprintln(w, "digraph definitions {"); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		n := nodes[k]
+		style := ""
+		if n.IsInlined {
+			style = ", style=dashed"
+		}
+		if _, err := fmt.Fprintf(w, "  %q [label=%q%s];\n", k, fmt.Sprintf("%s %s %s", n.Name, n.Version, n.Group), style); err != nil {
+			return err
+		}
+	}
+	for _, e := range edges {
+		style := ""
+		if e.inline {
+			style = ", style=dashed"
+		}
+		if _, err := fmt.Fprintf(w, "  %q -> %q [label=%q%s];\n", e.from, e.to, e.label, style); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+type dotReference struct {
+	field      string
+	definition *Definition
+}
+
+// dotReferences returns the definitions referenced by the fields of definition.  When collapse is true the
+// references of inlined definitions are returned in place of the inlined definition.
+func dotReferences(definition *Definition, prefix string, collapse bool, depth int) []dotReference {
+	refs := []dotReference{}
+	for _, field := range definition.Fields {
+		to := field.Definition
+		if to == nil {
+			to = field.valueDefinition
+		}
+		if to == nil || to == definition {
+			continue
+		}
+		if collapse && to.IsInlined {
+			if depth > 0 {
+				refs = append(refs, dotReferences(to, prefix+field.Name+".", collapse, depth-1)...)
+			}
+			continue
+		}
+		refs = append(refs, dotReference{field: prefix + field.Name, definition: to})
+	}
+	return refs
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newDOTDefinitions(t *testing.T) Definitions {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": object(schema{
+			"spec": ref("io.k8s.api.apps.v1.DeploymentSpec"),
+		}),
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{
+			"template": ref("io.k8s.api.core.v1.PodTemplateSpec"),
+		}),
+		"io.k8s.api.core.v1.PodTemplateSpec": object(schema{"name": str()}),
+		"io.k8s.api.core.v1.Pod":             object(schema{"kind": str()}),
+	})
+	for _, definition := range d.GetAllDefinitions() {
+		definition.InToc = definition.Name == "Deployment" || definition.Name == "Pod"
+	}
+	return d
+}
+
+func TestWriteDOT(t *testing.T) {
+	d := newDOTDefinitions(t)
+	tests := []struct {
+		opts     DOTOptions
+		expected string
+	}{
+		{DOTOptions{}, `digraph definitions {
+  "apps.v1.Deployment" [label="Deployment v1 apps"];
+  "apps.v1.DeploymentSpec" [label="DeploymentSpec v1 apps", style=dashed];
+  "core.v1.Pod" [label="Pod v1 core"];
+  "core.v1.PodTemplateSpec" [label="PodTemplateSpec v1 core"];
+  "apps.v1.Deployment" -> "apps.v1.DeploymentSpec" [label="spec", style=dashed];
+  "apps.v1.DeploymentSpec" -> "core.v1.PodTemplateSpec" [label="template"];
+}
+`},
+		{DOTOptions{CollapseInlined: true}, `digraph definitions {
+  "apps.v1.Deployment" [label="Deployment v1 apps"];
+  "core.v1.Pod" [label="Pod v1 core"];
+  "core.v1.PodTemplateSpec" [label="PodTemplateSpec v1 core"];
+  "apps.v1.Deployment" -> "core.v1.PodTemplateSpec" [label="spec.template"];
+}
+`},
+		{DOTOptions{Group: "apps"}, `digraph definitions {
+  "apps.v1.Deployment" [label="Deployment v1 apps"];
+  "apps.v1.DeploymentSpec" [label="DeploymentSpec v1 apps", style=dashed];
+  "apps.v1.Deployment" -> "apps.v1.DeploymentSpec" [label="spec", style=dashed];
+}
+`},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		if err := d.WriteDOT(&b, test.opts); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != test.expected {
+			t.Errorf("WriteDOT(%+v) =\n%s\nwant\n%s", test.opts, got, test.expected)
+		}
+	}
+}
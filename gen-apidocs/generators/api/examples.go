@@ -47,6 +47,8 @@ var EmptyExampleProviders = []ExampleProvider {
 func GetExampleProviders() []ExampleProvider {
 	if *BuildOps {
 		return ExampleProviders
+	} else if *AutoExamples {
+		return AutoExampleProviders
 	} else {
 		return EmptyExampleProviders
 	}
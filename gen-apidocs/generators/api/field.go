@@ -16,7 +16,12 @@ limitations under the License.
 
 package api
 
-import "strings"
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 type Fields []*Field
 
@@ -24,6 +29,31 @@ func (a Fields) Len() int           { return len(a) }
 func (a Fields) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a Fields) Less(i, j int) bool { return a[i].Name < a[j].Name }
 
+// FieldKind is the shape of a field's schema
+type FieldKind int
+
+const (
+	FieldKindUnknown FieldKind = iota
+	FieldKindScalar
+	FieldKindObject
+	FieldKindArray
+	FieldKindMap
+)
+
+func (k FieldKind) String() string {
+	switch k {
+	case FieldKindScalar:
+		return "scalar"
+	case FieldKindObject:
+		return "object"
+	case FieldKindArray:
+		return "array"
+	case FieldKindMap:
+		return "map"
+	}
+	return "unknown"
+}
+
 type Field struct {
 	Name        string
 	Type        string
@@ -34,6 +64,79 @@ type Field struct {
 	// Patch semantics
 	PatchStrategy string
 	PatchMergeKey string
+
+	// Required is true if the field is listed as required by its definition
+	Required bool
+	// Deprecated is true if the field description marks it as deprecated
+	Deprecated bool
+	// Experimental is true if the field references a definition in one of the ExperimentalGroups
+	Experimental bool
+	// Immutable is true if the field may not be changed after creation
+	Immutable bool
+	// UsesDeprecatedType is true if the field references a type matching DeprecatedRefPrefixes
+	UsesDeprecatedType bool
+	// DeprecatedType is the name of the deprecated type referenced by the field
+	DeprecatedType string
+
+	// Numeric bounds.  Bounds are inclusive unless the matching exclusive flag is set.
+	Minimum          *float64
+	ExclusiveMinimum bool
+	Maximum          *float64
+	ExclusiveMaximum bool
+	// Pattern is the regular expression string values must match
+	Pattern string
+	// Enum is the list of allowed values
+	Enum []string
+	// Default is the default value of the field, or of the definition it references, and nil if there is none
+	Default interface{}
+	// EnumDescriptions are the descriptions of the allowed values keyed by value
+	EnumDescriptions map[string]string
+
+	// InlinedFrom is the inlined definition the field was expanded from by EffectiveFields
+	InlinedFrom *Definition
+	// InlineBlockStart is true for the first field of each block of fields expanded from an inlined definition
+	// by EffectiveFields
+	InlineBlockStart bool
+
+	kind FieldKind
+	// valueDefinition is the definition of the values of map fields
+	valueDefinition *Definition
+	// unresolvedRef is the name of the definition referenced by the field when it is not found
+	unresolvedRef string
+}
+
+// Kind returns whether the field is a scalar, object, array or map
+func (f Field) Kind() FieldKind {
+	return f.kind
+}
+
+// referencedKey returns the key of the definition the field or its items or values reference
+func (f Field) referencedKey() string {
+	if f.Definition != nil {
+		return f.Definition.Key()
+	}
+	if f.valueDefinition != nil {
+		return f.valueDefinition.Key()
+	}
+	return ""
+}
+
+// ItemDefinition returns the definition of the elements of an array field.  Returns nil for
+// arrays of primitives and fields that are not arrays.
+func (f Field) ItemDefinition() *Definition {
+	if f.kind != FieldKindArray {
+		return nil
+	}
+	return f.Definition
+}
+
+// ValueDefinition returns the definition of the values of a map field.  Returns nil for
+// maps of primitives and fields that are not maps.
+func (f Field) ValueDefinition() *Definition {
+	if f.kind != FieldKindMap {
+		return nil
+	}
+	return f.valueDefinition
 }
 
 func (f Field) Link() string {
@@ -43,3 +146,143 @@ func (f Field) Link() string {
 		return f.Type
 	}
 }
+
+// ShortDescription returns the first sentence of the description, shortened to at most maxLen characters
+func (f Field) ShortDescription(maxLen int) string {
+	return shortDescription(f.Description, maxLen)
+}
+
+// Bounds returns the numeric bounds of the field for display e.g. "> 0, ≤ 100".  Empty if the field is unbounded.
+func (f Field) Bounds() string {
+	bounds := []string{}
+	if f.Minimum != nil {
+		op := "≥"
+		if f.ExclusiveMinimum {
+			op = ">"
+		}
+		bounds = append(bounds, fmt.Sprintf("%s %s", op, strconv.FormatFloat(*f.Minimum, 'f', -1, 64)))
+	}
+	if f.Maximum != nil {
+		op := "≤"
+		if f.ExclusiveMaximum {
+			op = "<"
+		}
+		bounds = append(bounds, fmt.Sprintf("%s %s", op, strconv.FormatFloat(*f.Maximum, 'f', -1, 64)))
+	}
+	return strings.Join(bounds, ", ")
+}
+
+// PatternHints maps well known field patterns to a human readable description of them.  Entries under
+// pattern_hints in config.yaml are added.
+var PatternHints = map[string]string{
+	"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$":                                    "a valid DNS label",
+	"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$": "a valid DNS subdomain",
+	"^[a-z]([-a-z0-9]*[a-z0-9])?$":                                       "a valid DNS-1035 label",
+	"^[A-Za-z_][A-Za-z0-9_]*$":                                           "a valid C identifier",
+}
+
+// PatternHint returns a human readable description of the field pattern, falling back to the raw pattern
+// if it is not found in PatternHints.  Empty if the field has no pattern.
+func (f Field) PatternHint() string {
+	if hint, found := PatternHints[f.Pattern]; found {
+		return hint
+	}
+	return f.Pattern
+}
+
+// maxInlineDepth bounds the depth of inlined definitions that are followed
+const maxInlineDepth = 10
+
+var JSONPathAnchors = flag.Bool("json-path-anchors", false, "If true, add anchors to fields using their kubectl explain path.")
+
+// FieldJSONPath returns the dotted path to the field from the root kind as used by kubectl explain
+// e.g. "deployment.spec.replicas".  Fields of inlined definitions are included with the full path to
+// them, as are the fields returned by EffectiveFields.  Returns "" if the field is not found.
+func (d *Definition) FieldJSONPath(f *Field) string {
+	if f.InlinedFrom != nil && d.isExpandedField(f) {
+		return strings.ToLower(d.Name) + "." + f.Name
+	}
+	if path, found := d.fieldPath(f, maxInlineDepth); found {
+		return strings.ToLower(d.Name) + "." + path
+	}
+	return ""
+}
+
+// FieldAnchor returns the anchor for the field if json path anchors are enabled
+func (d *Definition) FieldAnchor(f *Field) string {
+	if !*JSONPathAnchors {
+		return ""
+	}
+	return d.FieldJSONPath(f)
+}
+
+func (d *Definition) fieldPath(f *Field, depth int) (string, bool) {
+	for _, field := range d.Fields {
+		if field == f {
+			return field.Name, true
+		}
+	}
+	if depth <= 0 {
+		return "", false
+	}
+	for _, field := range d.Fields {
+		if field.Definition == nil || !field.Definition.IsInlined {
+			continue
+		}
+		if path, found := field.Definition.fieldPath(f, depth-1); found {
+			return field.Name + "." + path, true
+		}
+	}
+	return "", false
+}
+
+// isExpandedField returns true if f is a field expanded by EffectiveFields.  These are copies of the fields
+// of inlined definitions named with their path from d, so they are found by following the path.
+func (d *Definition) isExpandedField(f *Field) bool {
+	names := strings.Split(f.Name, ".")
+	definition := d
+	for _, name := range names[:len(names)-1] {
+		field, found := definition.GetField(name)
+		if !found || field.Definition == nil || !field.Definition.IsInlined {
+			return false
+		}
+		definition = field.Definition
+	}
+	_, found := definition.GetField(names[len(names)-1])
+	return found && definition == f.InlinedFrom
+}
+
+// EffectiveFields returns the fields of the definition with the fields of inlined definitions expanded in place
+// of the field referencing them.  Expanded fields are copies named with their path from the definition
+// e.g. "spec.replicas" and have InlinedFrom set to the inlined definition; the other fields are returned as is.
+// InlineBlockStart is set on the first field of each inlined definition and on fields resuming an inlined
+// definition after a definition nested in it.  FieldJSONPath resolves expanded fields by their path.
+func (d *Definition) EffectiveFields(defs *Definitions) Fields {
+	return d.effectiveFields("", nil, maxInlineDepth)
+}
+
+func (d *Definition) effectiveFields(prefix string, source *Definition, depth int) Fields {
+	fields := Fields{}
+	// start is true if the next field of d starts a block of fields from source
+	start := source != nil
+	for _, field := range d.Fields {
+		name := prefix + field.Name
+		if field.Definition != nil && field.Definition.IsInlined && depth > 0 {
+			fields = append(fields, field.Definition.effectiveFields(name+".", field.Definition, depth-1)...)
+			start = source != nil
+			continue
+		}
+		if source == nil {
+			// Fields of d itself are returned as is
+			fields = append(fields, field)
+			continue
+		}
+		f := *field
+		f.Name = name
+		f.InlinedFrom = source
+		f.InlineBlockStart = start
+		start = false
+		fields = append(fields, &f)
+	}
+	return fields
+}
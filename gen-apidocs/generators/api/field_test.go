@@ -0,0 +1,264 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{
+			"replicas":        schema{"type": "integer", "minimum": 0},
+			"minReadySeconds": schema{"type": "integer", "minimum": 0, "exclusiveMinimum": true, "maximum": 3600, "exclusiveMaximum": true},
+			"progress":        schema{"type": "number", "minimum": 0.5, "maximum": 100},
+			"revision":        schema{"type": "integer", "maximum": 10},
+			"paused":          schema{"type": "boolean"},
+		}),
+	})
+	tests := map[string]string{
+		"replicas":        "≥ 0",
+		"minReadySeconds": "> 0, < 3600",
+		"progress":        "≥ 0.5, ≤ 100",
+		"revision":        "≤ 10",
+		"paused":          "",
+	}
+	definition := mustGet(t, &d, "apps.v1.DeploymentSpec")
+	for name, expected := range tests {
+		field, found := definition.GetField(name)
+		if !found {
+			t.Fatalf("field %s not found", name)
+		}
+		if got := field.Bounds(); got != expected {
+			t.Errorf("%s Bounds() = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestImmutable(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.ConfigMap": object(schema{
+			"immutable": schema{"type": "boolean", "x-kubernetes-immutable": true},
+			"selector":  schema{"type": "string", "x-kubernetes-validations": []schema{{"rule": "self == oldSelf"}}},
+			"data":      schema{"type": "string", "x-kubernetes-immutable": false},
+			"name":      str(),
+		}),
+	})
+	tests := map[string]bool{
+		"immutable": true,
+		"selector":  true,
+		"data":      false,
+		"name":      false,
+	}
+	definition := mustGet(t, &d, "core.v1.ConfigMap")
+	for name, expected := range tests {
+		if field, _ := definition.GetField(name); field.Immutable != expected {
+			t.Errorf("%s Immutable = %v, want %v", name, field.Immutable, expected)
+		}
+	}
+}
+
+func TestItemAndValueDefinitions(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"containers":   schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+			"args":         schema{"type": "array", "items": str()},
+			"overhead":     schema{"type": "object", "additionalProperties": ref("io.k8s.api.core.v1.Quantity")},
+			"nodeSelector": schema{"type": "object", "additionalProperties": str()},
+			"affinity":     ref("io.k8s.api.core.v1.Affinity"),
+		}),
+		"io.k8s.api.core.v1.Container": object(schema{"name": str()}),
+		"io.k8s.api.core.v1.Quantity":  object(schema{"value": str()}),
+		"io.k8s.api.core.v1.Affinity":  object(schema{"kind": str()}),
+	})
+	container := mustGet(t, &d, "core.v1.Container")
+	quantity := mustGet(t, &d, "core.v1.Quantity")
+	tests := []struct {
+		name  string
+		item  *Definition
+		value *Definition
+	}{
+		{"containers", container, nil},
+		{"args", nil, nil},
+		{"overhead", nil, quantity},
+		{"nodeSelector", nil, nil},
+		{"affinity", nil, nil},
+	}
+	definition := mustGet(t, &d, "core.v1.PodSpec")
+	for _, test := range tests {
+		field, _ := definition.GetField(test.name)
+		if got := field.ItemDefinition(); got != test.item {
+			t.Errorf("%s ItemDefinition() = %v, want %v", test.name, got, test.item)
+		}
+		if got := field.ValueDefinition(); got != test.value {
+			t.Errorf("%s ValueDefinition() = %v, want %v", test.name, got, test.value)
+		}
+	}
+}
+
+func newDeploymentDefinitions(t *testing.T) Definitions {
+	return newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": object(schema{
+			"kind": str(),
+			"spec": ref("io.k8s.api.apps.v1.DeploymentSpec"),
+		}),
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{
+			"replicas": schema{"type": "integer"},
+			"strategy": ref("io.k8s.api.apps.v1.DeploymentStrategy"),
+		}),
+		"io.k8s.api.apps.v1.DeploymentStrategy": object(schema{
+			"type": str(),
+		}),
+	})
+}
+
+func TestFieldJSONPath(t *testing.T) {
+	d := newDeploymentDefinitions(t)
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	kind, _ := deployment.GetField("kind")
+	replicas, _ := mustGet(t, &d, "apps.v1.DeploymentSpec").GetField("replicas")
+	strategyType, _ := mustGet(t, &d, "apps.v1.DeploymentStrategy").GetField("type")
+	tests := []struct {
+		field    *Field
+		expected string
+	}{
+		{kind, "deployment.kind"},
+		{replicas, "deployment.spec.replicas"},
+		{strategyType, "deployment.spec.strategy.type"},
+		{&Field{Name: "other"}, ""},
+	}
+	for _, test := range tests {
+		if got := deployment.FieldJSONPath(test.field); got != test.expected {
+			t.Errorf("FieldJSONPath(%s) = %q, want %q", test.field.Name, got, test.expected)
+		}
+	}
+}
+
+func TestEffectiveFields(t *testing.T) {
+	d := newDeploymentDefinitions(t)
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	fields := deployment.EffectiveFields(&d)
+	if got, expected := fieldNames(fields), []string{"kind", "spec.replicas", "spec.strategy.type"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("EffectiveFields() = %v, want %v", got, expected)
+	}
+	if got, expected := fieldNames(deployment.Fields), []string{"kind", "spec"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("EffectiveFields() changed Fields to %v, want %v", got, expected)
+	}
+	for _, f := range fields {
+		if got, expected := deployment.FieldJSONPath(f), "deployment."+f.Name; got != expected {
+			t.Errorf("FieldJSONPath(%s) = %q, want %q", f.Name, got, expected)
+		}
+	}
+}
+
+func TestPatternHint(t *testing.T) {
+	tests := map[string]string{
+		"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$": "a valid DNS label",
+		"^[0-9]+[a-z]$":                   "^[0-9]+[a-z]$",
+		"":                                "",
+	}
+	for pattern, expected := range tests {
+		if got := (Field{Pattern: pattern}).PatternHint(); got != expected {
+			t.Errorf("PatternHint(%q) = %q, want %q", pattern, got, expected)
+		}
+	}
+}
+
+func TestEffectiveFieldsBlocks(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": object(schema{
+			"kind":   str(),
+			"spec":   ref("io.k8s.api.apps.v1.DeploymentSpec"),
+			"status": str(),
+		}),
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{
+			"replicas": schema{"type": "integer"},
+			"strategy": ref("io.k8s.api.apps.v1.DeploymentStrategy"),
+			"template": str(),
+		}),
+		"io.k8s.api.apps.v1.DeploymentStrategy": object(schema{
+			"rollingUpdate": ref("io.k8s.api.apps.v1.RollingUpdateDeployment"),
+			"type":          str(),
+		}),
+		"io.k8s.api.apps.v1.RollingUpdateDeployment": object(schema{"maxSurge": str()}),
+	})
+	type block struct {
+		name  string
+		from  string
+		start bool
+	}
+	expected := []block{
+		{"kind", "", false},
+		{"spec.replicas", "DeploymentSpec", true},
+		{"spec.strategy.rollingUpdate.maxSurge", "RollingUpdateDeployment", true},
+		{"spec.strategy.type", "DeploymentStrategy", true},
+		{"spec.template", "DeploymentSpec", true},
+		{"status", "", false},
+	}
+	got := []block{}
+	for _, f := range mustGet(t, &d, "apps.v1.Deployment").EffectiveFields(&d) {
+		from := ""
+		if f.InlinedFrom != nil {
+			from = f.InlinedFrom.Name
+		}
+		got = append(got, block{f.Name, from, f.InlineBlockStart})
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("EffectiveFields() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestEffectiveFieldsJSONPath(t *testing.T) {
+	setFlag(t, "json-path-anchors", "true")
+	d := newDefinitions(t, schema{
+		"io.k8s.api.apps.v1.Deployment": object(schema{
+			"kind": str(),
+			"spec": ref("io.k8s.api.apps.v1.DeploymentSpec"),
+		}),
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{
+			"replicas": schema{"type": "integer"},
+			"strategy": ref("io.k8s.api.apps.v1.DeploymentStrategy"),
+			"template": str(),
+		}),
+		"io.k8s.api.apps.v1.DeploymentStrategy": object(schema{"type": str()}),
+	})
+	type block struct {
+		anchor string
+		start  bool
+	}
+	expected := []block{
+		{"deployment.kind", false},
+		{"deployment.spec.replicas", true},
+		{"deployment.spec.strategy.type", true},
+		{"deployment.spec.template", true},
+	}
+	deployment := mustGet(t, &d, "apps.v1.Deployment")
+	got := []block{}
+	for _, f := range deployment.EffectiveFields(&d) {
+		got = append(got, block{deployment.FieldAnchor(f), f.InlineBlockStart})
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("EffectiveFields() anchors = %+v, want %+v", got, expected)
+	}
+
+	// A copy expanded from another definition is not a field of the deployment
+	other := &Field{Name: "spec.replicas", InlinedFrom: mustGet(t, &d, "apps.v1.DeploymentStrategy")}
+	if got := deployment.FieldJSONPath(other); got != "" {
+		t.Errorf("FieldJSONPath(%s from DeploymentStrategy) = %q, want \"\"", other.Name, got)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/go-openapi/loads"
+)
+
+// schema is a json schema written as a map for building test specs
+type schema map[string]interface{}
+
+// ref returns a schema referencing the definition name
+func ref(name string) schema {
+	return schema{"$ref": "#/definitions/" + name}
+}
+
+// object returns an object schema with the properties
+func object(properties schema) schema {
+	return schema{"type": "object", "properties": properties}
+}
+
+func str() schema {
+	return schema{"type": "string"}
+}
+
+// describedStr returns a string schema with the description
+func describedStr(description string) schema {
+	return schema{"type": "string", "description": description}
+}
+
+// swagger returns an open-api v2 document containing the definitions
+func swagger(definitions schema) schema {
+	return schema{
+		"swagger":     "2.0",
+		"info":        schema{"title": "test", "version": "v1"},
+		"paths":       schema{},
+		"definitions": definitions,
+	}
+}
+
+// newSpec returns an open-api document containing the definitions
+func newSpec(t *testing.T, definitions schema) *loads.Document {
+	t.Helper()
+	b, err := json.Marshal(swagger(definitions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := loads.Analyzed(json.RawMessage(b), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// newDefinitions builds the Definitions for a spec containing the definitions
+func newDefinitions(t *testing.T, definitions schema) Definitions {
+	t.Helper()
+	return GetDefinitions([]*loads.Document{newSpec(t, definitions)})
+}
+
+// mustGet returns the definition with key or fails the test
+func mustGet(t *testing.T, d *Definitions, key string) *Definition {
+	t.Helper()
+	definition, found := d.GetByKey(key)
+	if !found {
+		t.Fatalf("definition %s not found", key)
+	}
+	return definition
+}
+
+// setFlag sets the flag for the duration of the test
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+	f := flag.Lookup(name)
+	if f == nil {
+		t.Fatalf("flag %s not found", name)
+	}
+	old := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Value.Set(old) })
+}
+
+// fieldNames returns the names of fields
+func fieldNames(fields Fields) []string {
+	names := []string{}
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// keys returns the keys of definitions
+func keys(definitions []*Definition) []string {
+	keys := []string{}
+	for _, definition := range definitions {
+		keys = append(keys, definition.Key())
+	}
+	return keys
+}
@@ -32,6 +32,10 @@ var INLINE_DEFINITIONS = []InlineDefinition{
 	{Name: "EventSource", Match: "${resource}EventSource"},
 }
 
+// DefinitionReplacements maps the key of a deprecated definition (group.version.kind) to the key of
+// the definition replacing it.  Unlike OtherVersions and NewerVersions, the replacement may live in another group.
+var DefinitionReplacements = map[string]string{}
+
 const (
 	path  = "path"
 	query = "query"
@@ -52,6 +56,22 @@ func (definitions Definitions) initInlinedDefinitions() Definitions {
 	return definitions
 }
 
+// Link deprecated definitions to their replacements
+func (definitions Definitions) initReplacedBy() Definitions {
+	for key, replacement := range DefinitionReplacements {
+		d, found := definitions.GetByKey(key)
+		if !found {
+			continue
+		}
+		if r, found := definitions.GetByKey(replacement); found {
+			d.ReplacedBy = r
+		} else {
+			fmt.Printf("Could not locate replacement %s for %s.\n", replacement, key)
+		}
+	}
+	return definitions
+}
+
 // Build the "Appears In" index for definitions
 func (definitions Definitions) initAppearsIn() Definitions {
 	for _, d := range definitions.GetAllDefinitions() {
@@ -66,7 +86,10 @@ func (definitions Definitions) initAppearsIn() Definitions {
 func getDefinitionFieldDefinitions(definition *Definition, definitions Definitions) []*Definition {
 	children := []*Definition{}
 	// Find all of the resources referenced by this definition
-	for _, p := range definition.schema.Properties {
+	for name, p := range definition.schema.Properties {
+		if isHiddenField(name) {
+			continue
+		}
 		if !definitions.IsComplex(p) {
 			// Skip primitive types and collections of primitive types
 			continue
@@ -155,6 +178,7 @@ func (definitions *Definitions) parameterToField(parameter spec.Parameter) *Fiel
 	}
 	if parameter.Schema != nil {
 		field.Type = GetTypeName(*parameter.Schema)
+		field.kind = GetFieldKind(*parameter.Schema)
 		if fieldType, f := definitions.GetForSchema(*parameter.Schema); f {
 			field.Definition = fieldType
 		}
@@ -0,0 +1,40 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+)
+
+func TestReplacedBy(t *testing.T) {
+	saved := DefinitionReplacements
+	DefinitionReplacements = map[string]string{"extensions.v1beta1.Deployment": "apps.v1beta1.Deployment"}
+	t.Cleanup(func() { DefinitionReplacements = saved })
+
+	d := newDefinitions(t, schema{
+		"io.k8s.api.extensions.v1beta1.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Deployment":       object(schema{"kind": str()}),
+	})
+	extensions := mustGet(t, &d, "extensions.v1beta1.Deployment")
+	apps := mustGet(t, &d, "apps.v1beta1.Deployment")
+	if extensions.ReplacedBy != apps {
+		t.Errorf("extensions Deployment ReplacedBy = %v, want apps Deployment", extensions.ReplacedBy)
+	}
+	if apps.ReplacedBy != nil {
+		t.Errorf("apps Deployment ReplacedBy = %v, want nil", apps.ReplacedBy.Key())
+	}
+}
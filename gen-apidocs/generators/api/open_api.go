@@ -17,33 +17,54 @@ limitations under the License.
 package api
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-openapi/loads"
 )
 
+// specIndexFile is the name of the open-api v3 discovery document listing the per group spec files
+const specIndexFile = "index.json"
+
 // Loads all of the open-api documents
 func LoadOpenApiSpec() []*loads.Document {
 	dir := filepath.Join(*ConfigDir, "openapi-spec/")
+	docs, err := LoadSpecDir(dir)
+	if err != nil {
+		os.Stderr.WriteString(fmt.Sprintf("%v", err))
+		os.Exit(1)
+	}
+	return docs
+}
+
+// LoadSpecDir loads every json file under dir as an open-api document, skipping the open-api v3 index file.
+// Returns an error naming each file that could not be loaded.
+func LoadSpecDir(dir string) ([]*loads.Document, error) {
 	docs := []*loads.Document{}
+	errs := []string{}
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		ext := filepath.Ext(path)
-		if ext != ".json" {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" || info.Name() == specIndexFile {
 			return nil
 		}
-		var d *loads.Document
-		d, err = loads.JSONSpec(path)
+		d, err := loads.JSONSpec(path)
 		if err != nil {
-			return fmt.Errorf("Could not load json file %s as api-spec: %v\n", path, err)
+			errs = append(errs, fmt.Sprintf("Could not load json file %s as api-spec: %v", path, err))
+			return nil
 		}
 		docs = append(docs, d)
 		return nil
 	})
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("%v", err))
-		os.Exit(1)
+		return nil, err
 	}
-	return docs
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+	return docs, nil
 }
@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+// WriteOpenAPISubset writes an open-api document containing the schemas of the definitions loaded from specs.
+// To write a subset, Put the definitions to keep into a set created with NewDefinitions.  References to
+// definitions outside of the set are written as stub object schemas so the document stays valid.
+func (d *Definitions) WriteOpenAPISubset(w io.Writer) error {
+	definitions := spec.Definitions{}
+	for _, definition := range d.GetAllDefinitions() {
+		if len(definition.FullName) == 0 {
+			continue
+		}
+		definitions[definition.FullName] = definition.schema
+	}
+
+	refs := map[string]bool{}
+	for _, s := range definitions {
+		collectRefs(s, refs)
+	}
+	missing := []string{}
+	for name := range refs {
+		if _, found := definitions[name]; !found {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		stub := spec.Schema{}
+		stub.Type = spec.StringOrArray{"object"}
+		stub.Description = fmt.Sprintf("%s is not included in this document.", name)
+		definitions[name] = stub
+	}
+
+	swagger := spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Swagger:     "2.0",
+			Info:        &spec.Info{InfoProps: spec.InfoProps{Title: "Kubernetes", Version: "unversioned"}},
+			Paths:       &spec.Paths{Paths: map[string]spec.PathItem{}},
+			Definitions: definitions,
+		},
+	}
+	b, err := json.MarshalIndent(swagger, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// collectRefs adds the names of the definitions referenced by s to refs
+func collectRefs(s spec.Schema, refs map[string]bool) {
+	if IsDefinition(s) {
+		refs[definitionNameForRef(s.Ref)] = true
+	}
+	for _, p := range s.Properties {
+		collectRefs(p, refs)
+	}
+	for _, c := range s.AllOf {
+		collectRefs(c, refs)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		collectRefs(*s.Items.Schema, refs)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		collectRefs(*s.AdditionalProperties.Schema, refs)
+	}
+}
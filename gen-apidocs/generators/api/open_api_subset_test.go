@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/loads"
+)
+
+func TestWriteOpenAPISubset(t *testing.T) {
+	all := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"kind": str(),
+			"spec": ref("io.k8s.api.core.v1.PodSpec"),
+		}),
+		"io.k8s.api.core.v1.PodSpec": object(schema{"hostname": str()}),
+		"io.k8s.api.core.v1.Node":    object(schema{"kind": str()}),
+	})
+	subset := NewDefinitions()
+	subset.Put(mustGet(t, &all, "core.v1.Pod"))
+
+	var b bytes.Buffer
+	if err := subset.WriteOpenAPISubset(&b); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := loads.Analyzed(json.RawMessage(b.Bytes()), "")
+	if err != nil {
+		t.Fatalf("could not reload the written subset: %v", err)
+	}
+	reloaded := GetDefinitions([]*loads.Document{doc})
+
+	if got, expected := keys(reloaded.CurrentVersionsOnly()), []string{"core.v1.Pod", "core.v1.PodSpec"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("reloaded definitions = %v, want %v", got, expected)
+	}
+	pod := mustGet(t, &reloaded, "core.v1.Pod")
+	if got, expected := fieldNames(pod.Fields), []string{"kind", "spec"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("reloaded Pod fields = %v, want %v", got, expected)
+	}
+	stub := mustGet(t, &reloaded, "core.v1.PodSpec")
+	if field, _ := pod.GetField("spec"); field.Definition != stub {
+		t.Errorf("reloaded spec field does not reference the PodSpec stub")
+	}
+	if len(stub.Fields) != 0 || stub.Description() != "io.k8s.api.core.v1.PodSpec is not included in this document." {
+		t.Errorf("PodSpec stub = %v %q, want an empty object noting it is not included", fieldNames(stub.Fields), stub.Description())
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeSpecDir writes each spec to a json file named by its key in a temporary directory
+func writeSpecDir(t *testing.T, specs map[string]schema) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "openapi-spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for name, s := range specs {
+		b, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadSpecDir(t *testing.T) {
+	dir := writeSpecDir(t, map[string]schema{
+		"core.json":  swagger(schema{"io.k8s.api.core.v1.Pod": object(schema{"kind": str()})}),
+		"apps.json":  swagger(schema{"io.k8s.api.apps.v1.Deployment": object(schema{"kind": str()})}),
+		"index.json": schema{"paths": schema{}},
+		"README.md":  schema{},
+	})
+	docs, err := LoadSpecDir(dir)
+	if err != nil {
+		t.Fatalf("LoadSpecDir() error = %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("LoadSpecDir() = %d documents, want 2", len(docs))
+	}
+	d := GetDefinitions(docs)
+	if got, expected := keys(d.CurrentVersionsOnly()), []string{"apps.v1.Deployment", "core.v1.Pod"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("definitions = %v, want %v", got, expected)
+	}
+}
+
+func TestLoadSpecDirInvalidFile(t *testing.T) {
+	dir := writeSpecDir(t, map[string]schema{
+		"core.json": swagger(schema{"io.k8s.api.core.v1.Pod": object(schema{"kind": str()})}),
+	})
+	if err := ioutil.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSpecDir(dir); err == nil {
+		t.Errorf("LoadSpecDir() error = nil, want an error for broken.json")
+	}
+}
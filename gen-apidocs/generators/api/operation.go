@@ -70,6 +70,11 @@ type Operation struct {
 	ExampleConfig ExampleConfig
 }
 
+// IsWatch returns true if the operation streams changes to its resource
+func (o *Operation) IsWatch() bool {
+	return strings.HasPrefix(o.Type.Name, "Watch") || strings.HasPrefix(o.ID, "watch")
+}
+
 type ExampleText struct {
 	Tab  string
 	Type string
@@ -0,0 +1,56 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import "strings"
+
+// PluralRule replaces Suffix with Plural when pluralizing a kind ending in Suffix
+type PluralRule struct {
+	Suffix string
+	Plural string
+}
+
+// PluralRules are applied in order when pluralizing kinds.  The first matching rule wins.  Kinds
+// matching no rule have "s" appended.
+var PluralRules = []PluralRule{
+	{Suffix: "ay", Plural: "ays"},
+	{Suffix: "ey", Plural: "eys"},
+	{Suffix: "oy", Plural: "oys"},
+	{Suffix: "y", Plural: "ies"},
+	{Suffix: "s", Plural: "ses"},
+	{Suffix: "x", Plural: "xes"},
+	{Suffix: "ch", Plural: "ches"},
+	{Suffix: "sh", Plural: "shes"},
+}
+
+// PluralIrregulars are kinds whose plural does not follow the PluralRules
+var PluralIrregulars = map[string]string{
+	"Endpoints": "Endpoints",
+}
+
+// Pluralize returns the plural form of kind e.g. "NetworkPolicy" -> "NetworkPolicies"
+func Pluralize(kind string) string {
+	if plural, found := PluralIrregulars[kind]; found {
+		return plural
+	}
+	for _, rule := range PluralRules {
+		if strings.HasSuffix(kind, rule.Suffix) {
+			return strings.TrimSuffix(kind, rule.Suffix) + rule.Plural
+		}
+	}
+	return kind + "s"
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var FailOnUnresolved = flag.Bool("fail-on-unresolved", false, "If true, fail if a field references a definition that is not found.")
+
+// FieldRef identifies a field of a definition
+type FieldRef struct {
+	// Key is the key of the definition owning the field
+	Key   string
+	Field string
+}
+
+type FieldRefs []FieldRef
+
+func (a FieldRefs) Len() int      { return len(a) }
+func (a FieldRefs) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a FieldRefs) Less(i, j int) bool {
+	if a[i].Key != a[j].Key {
+		return a[i].Key < a[j].Key
+	}
+	return a[i].Field < a[j].Field
+}
+
+// FindReferences returns the fields of all definitions that reference the definition with key, either
+// directly or through the items of an array or the values of a map
+func (d *Definitions) FindReferences(key string) []FieldRef {
+	refs := FieldRefs{}
+	for owner, definition := range d.GetAllDefinitions() {
+		for _, field := range definition.Fields {
+			if field.referencedKey() == key {
+				refs = append(refs, FieldRef{Key: owner, Field: field.Name})
+			}
+		}
+	}
+	sort.Sort(refs)
+	return refs
+}
+
+// RedundantFieldDescriptions returns the fields whose description repeats the description of the definition
+// they reference, ignoring whitespace and case
+func (d *Definitions) RedundantFieldDescriptions() []FieldRef {
+	refs := FieldRefs{}
+	for owner, definition := range d.GetAllDefinitions() {
+		for _, field := range definition.Fields {
+			if field.Definition == nil {
+				continue
+			}
+			description := normalizeWhitespace(field.Description)
+			if len(description) > 0 && strings.EqualFold(description, normalizeWhitespace(field.Definition.Description())) {
+				refs = append(refs, FieldRef{Key: owner, Field: field.Name})
+			}
+		}
+	}
+	sort.Sort(refs)
+	return refs
+}
+
+// UnresolvedFields returns the fields referencing a definition, directly or through array items or map values,
+// that is not found
+func (d *Definitions) UnresolvedFields() []FieldRef {
+	refs := FieldRefs{}
+	for _, f := range d.unresolvedFields() {
+		refs = append(refs, f.FieldRef)
+	}
+	return refs
+}
+
+// unresolvedField is a field and the name of the definition it references that is not found
+type unresolvedField struct {
+	FieldRef
+	name string
+}
+
+// unresolvedFields returns the unresolved fields of all definitions ordered by FieldRef
+func (d *Definitions) unresolvedFields() []unresolvedField {
+	refs := FieldRefs{}
+	names := map[FieldRef]string{}
+	for owner, definition := range d.GetAllDefinitions() {
+		for _, field := range definition.Fields {
+			if len(field.unresolvedRef) > 0 {
+				ref := FieldRef{Key: owner, Field: field.Name}
+				refs = append(refs, ref)
+				names[ref] = field.unresolvedRef
+			}
+		}
+	}
+	sort.Sort(refs)
+	fields := []unresolvedField{}
+	for _, ref := range refs {
+		fields = append(fields, unresolvedField{FieldRef: ref, name: names[ref]})
+	}
+	return fields
+}
+
+// unresolvedFieldsError returns an error listing each unresolved field and the definition it references
+func (d *Definitions) unresolvedFieldsError() error {
+	fields := d.unresolvedFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	lines := []string{fmt.Sprintf("%d fields reference definitions that are not found:", len(fields))}
+	for _, f := range fields {
+		lines = append(lines, fmt.Sprintf("%s.%s -> %s", f.Key, f.Field, f.name))
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindReferences(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"containers":     schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+			"initContainers": schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+			"hostname":       str(),
+		}),
+		"io.k8s.api.core.v1.EphemeralContainers": object(schema{
+			"byName": schema{"type": "object", "additionalProperties": ref("io.k8s.api.core.v1.Container")},
+		}),
+		"io.k8s.api.core.v1.Container": object(schema{"name": str()}),
+	})
+	expected := []FieldRef{
+		{Key: "core.v1.EphemeralContainers", Field: "byName"},
+		{Key: "core.v1.PodSpec", Field: "containers"},
+		{Key: "core.v1.PodSpec", Field: "initContainers"},
+	}
+	if got := d.FindReferences("core.v1.Container"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FindReferences() = %v, want %v", got, expected)
+	}
+	if got := d.FindReferences("core.v1.PodSpec"); len(got) != 0 {
+		t.Errorf("FindReferences() of an unreferenced definition = %v, want none", got)
+	}
+}
+
+func TestRedundantFieldDescriptions(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"spec":     schema{"$ref": "#/definitions/io.k8s.api.core.v1.PodSpec", "description": "podSpec is  a description of a pod."},
+			"status":   schema{"$ref": "#/definitions/io.k8s.api.core.v1.PodStatus", "description": "Most recently observed status."},
+			"metadata": ref("io.k8s.api.core.v1.ObjectMeta"),
+			"name":     describedStr("PodSpec is a description of a pod."),
+		}),
+		"io.k8s.api.core.v1.PodSpec":    schema{"type": "object", "description": "PodSpec is a description\nof a pod."},
+		"io.k8s.api.core.v1.PodStatus":  schema{"type": "object", "description": "PodStatus is the status of a pod."},
+		"io.k8s.api.core.v1.ObjectMeta": schema{"type": "object", "description": "Standard object metadata."},
+	})
+	expected := []FieldRef{{Key: "core.v1.Pod", Field: "spec"}}
+	if got := d.RedundantFieldDescriptions(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RedundantFieldDescriptions() = %v, want %v", got, expected)
+	}
+}
+
+func TestUnresolvedFields(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"affinity":   ref("io.k8s.api.core.v1.Affinity"),
+			"containers": schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+			"overhead":   schema{"type": "object", "additionalProperties": ref("io.k8s.api.core.v1.Quantity")},
+			"port":       ref("io.k8s.apimachinery.pkg.util.intstr.IntOrString"),
+			"volumes":    schema{"type": "array", "items": ref("io.k8s.api.core.v1.Volume")},
+		}),
+		"io.k8s.api.core.v1.Volume":  object(schema{"name": str()}),
+		"io.k8s.api.core.v1.Service": object(schema{"affinity": ref("io.k8s.api.core.v1.Affinity")}),
+	})
+	expected := []FieldRef{
+		{Key: "core.v1.PodSpec", Field: "affinity"},
+		{Key: "core.v1.PodSpec", Field: "containers"},
+		{Key: "core.v1.PodSpec", Field: "overhead"},
+		{Key: "core.v1.Service", Field: "affinity"},
+	}
+	if got := d.UnresolvedFields(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("UnresolvedFields() = %v, want %v", got, expected)
+	}
+	refs := []string{"io.k8s.api.core.v1.Affinity", "io.k8s.api.core.v1.Container", "io.k8s.api.core.v1.Quantity"}
+	if got := d.UnresolvedRefs(); !reflect.DeepEqual(got, refs) {
+		t.Errorf("UnresolvedRefs() = %v, want %v", got, refs)
+	}
+	message := `4 fields reference definitions that are not found:
+core.v1.PodSpec.affinity -> io.k8s.api.core.v1.Affinity
+core.v1.PodSpec.containers -> io.k8s.api.core.v1.Container
+core.v1.PodSpec.overhead -> io.k8s.api.core.v1.Quantity
+core.v1.Service.affinity -> io.k8s.api.core.v1.Affinity`
+	if err := d.unresolvedFieldsError(); err == nil || err.Error() != message {
+		t.Errorf("unresolvedFieldsError() = %v, want\n%s", err, message)
+	}
+}
+
+func TestUnresolvedFieldsResolved(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"containers": schema{"type": "array", "items": ref("io.k8s.api.core.v1.Container")},
+			"port":       ref("io.k8s.apimachinery.pkg.util.intstr.IntOrString"),
+		}),
+		"io.k8s.api.core.v1.Container": object(schema{"name": str()}),
+	})
+	if got := d.UnresolvedFields(); len(got) != 0 {
+		t.Errorf("UnresolvedFields() = %v, want none", got)
+	}
+	if got := d.UnresolvedRefs(); len(got) != 0 {
+		t.Errorf("UnresolvedRefs() = %v, want none", got)
+	}
+	if err := d.unresolvedFieldsError(); err != nil {
+		t.Errorf("unresolvedFieldsError() = %v, want nil", err)
+	}
+}
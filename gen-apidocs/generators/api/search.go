@@ -0,0 +1,132 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"flag"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var IndexDescriptions = flag.Bool("index-descriptions", false, "If true, include description words in the search index.")
+
+type SearchResultType string
+
+const (
+	SearchDefinition SearchResultType = "definition"
+	SearchField      SearchResultType = "field"
+)
+
+// SearchResult is a single hit in the search index
+type SearchResult struct {
+	Type SearchResultType
+	// Key is the key of the matching definition, or of the definition owning the matching field
+	Key string
+	// Field is the name of the matching field.  Empty for definition results.
+	Field string
+}
+
+type SearchResults []SearchResult
+
+func (a SearchResults) Len() int      { return len(a) }
+func (a SearchResults) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a SearchResults) Less(i, j int) bool {
+	if a[i].Key != a[j].Key {
+		return a[i].Key < a[j].Key
+	}
+	if a[i].Type != a[j].Type {
+		return a[i].Type < a[j].Type
+	}
+	return a[i].Field < a[j].Field
+}
+
+// SearchIndex returns an index from lowercased tokens to the definitions and fields they appear in.
+// The index is built on first use and reused afterwards.
+func (d *Definitions) SearchIndex() map[string][]SearchResult {
+	if d.searchIndex != nil {
+		return d.searchIndex
+	}
+
+	index := map[string]SearchResults{}
+	add := func(tokens []string, r SearchResult) {
+		for _, t := range tokens {
+			index[t] = append(index[t], r)
+		}
+	}
+
+	keys := []string{}
+	for k := range d.GetAllDefinitions() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		definition := d.ByGroupVersionKind[k]
+		r := SearchResult{Type: SearchDefinition, Key: k}
+		add(tokenizeName(definition.Name), r)
+		if *IndexDescriptions {
+			add(tokenizeText(definition.Description()), r)
+		}
+		for _, field := range definition.Fields {
+			r := SearchResult{Type: SearchField, Key: k, Field: field.Name}
+			add(tokenizeName(field.Name), r)
+			if *IndexDescriptions {
+				add(tokenizeText(field.Description), r)
+			}
+		}
+	}
+
+	d.searchIndex = map[string][]SearchResult{}
+	for t, results := range index {
+		sort.Sort(results)
+		dedup := SearchResults{}
+		for i, r := range results {
+			if i > 0 && r == results[i-1] {
+				continue
+			}
+			dedup = append(dedup, r)
+		}
+		d.searchIndex[t] = dedup
+	}
+	return d.searchIndex
+}
+
+// tokenizeName returns the lowercased name along with each of its camel case words
+// e.g. "APIGroupSpec" -> ["apigroupspec", "api", "group", "spec"]
+func tokenizeName(name string) []string {
+	tokens := []string{strings.ToLower(name)}
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		atEnd := i == len(runes)
+		if atEnd || (unicode.IsUpper(runes[i]) && (!unicode.IsUpper(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1])))) {
+			if word := strings.ToLower(string(runes[start:i])); word != tokens[0] {
+				tokens = append(tokens, word)
+			}
+			start = i
+		}
+	}
+	return tokens
+}
+
+// tokenizeText returns the lowercased words appearing in free form text
+func tokenizeText(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
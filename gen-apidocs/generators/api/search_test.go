@@ -0,0 +1,55 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchIndex(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec":        object(schema{"restartPolicy": str()}),
+		"io.k8s.api.apps.v1.DeploymentSpec": object(schema{"restartPolicy": str(), "replicas": schema{"type": "integer"}}),
+	})
+
+	index := d.SearchIndex()
+	expected := []SearchResult{
+		{Type: SearchField, Key: "apps.v1.DeploymentSpec", Field: "restartPolicy"},
+		{Type: SearchField, Key: "core.v1.PodSpec", Field: "restartPolicy"},
+	}
+	if got := index["policy"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("index[policy] = %v, want %v", got, expected)
+	}
+	if got := index["restartpolicy"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("index[restartpolicy] = %v, want %v", got, expected)
+	}
+	expected = []SearchResult{{Type: SearchDefinition, Key: "core.v1.PodSpec"}}
+	if got := index["pod"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("index[pod] = %v, want %v", got, expected)
+	}
+	if _, found := index["missing"]; found {
+		t.Errorf("index[missing] found, want not found")
+	}
+}
+
+func TestTokenizeName(t *testing.T) {
+	expected := []string{"apigroupspec", "api", "group", "spec"}
+	if got := tokenizeName("APIGroupSpec"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("tokenizeName(APIGroupSpec) = %v, want %v", got, expected)
+	}
+}
@@ -22,9 +22,11 @@ type SortDefinitionsByName []*Definition
 
 func (a SortDefinitionsByName) Len() int      { return len(a) }
 func (a SortDefinitionsByName) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// Less orders definitions by name.  Definitions with the same name are ordered by version and then group.
 func (a SortDefinitionsByName) Less(i, j int) bool {
 	if a[i].Name == a[j].Name {
-		return a[i].Version.LessThan(a[j].Version)
+		return SortDefinitionsByVersion(a).Less(i, j)
 	}
 	return a[i].Name < a[j].Name
 }
@@ -33,11 +35,45 @@ type SortDefinitionsByVersion []*Definition
 
 func (a SortDefinitionsByVersion) Len() int      { return len(a) }
 func (a SortDefinitionsByVersion) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// Less orders newer versions first.  Versions that rank the same (e.g. two unrecognized versions) are ordered
+// by group and then version name so the order does not depend on the order definitions were found in.
 func (a SortDefinitionsByVersion) Less(i, j int) bool {
 	switch {
-	case a[i].Version == a[j].Version:
+	case a[i].Version.LessThan(a[j].Version):
+		return true
+	case a[j].Version.LessThan(a[i].Version):
+		return false
+	case a[i].Group != a[j].Group:
 		return strings.Compare(a[i].Group.String(), a[j].Group.String()) < 0
 	default:
-		return a[i].Version.LessThan(a[j].Version)
+		return strings.Compare(a[i].Version.String(), a[j].Version.String()) < 0
+	}
+}
+
+type SortDefinitionsByGroupKind []*Definition
+
+func (a SortDefinitionsByGroupKind) Len() int      { return len(a) }
+func (a SortDefinitionsByGroupKind) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a SortDefinitionsByGroupKind) Less(i, j int) bool {
+	if a[i].Group != a[j].Group {
+		return strings.Compare(a[i].Group.String(), a[j].Group.String()) < 0
+	}
+	if a[i].Name != a[j].Name {
+		return a[i].Name < a[j].Name
+	}
+	return a[i].Version.LessThan(a[j].Version)
+}
+
+// SortDefinitionsByWeight orders definitions with lower TocWeights first, and definitions of equal weight
+// by name
+type SortDefinitionsByWeight []*Definition
+
+func (a SortDefinitionsByWeight) Len() int      { return len(a) }
+func (a SortDefinitionsByWeight) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a SortDefinitionsByWeight) Less(i, j int) bool {
+	if wi, wj := a[i].TocWeight(), a[j].TocWeight(); wi != wj {
+		return wi < wj
 	}
+	return SortDefinitionsByName(a).Less(i, j)
 }
@@ -0,0 +1,41 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSortDefinitionsByName(t *testing.T) {
+	expected := []string{"core.v1.Event", "events.v1.Event", "events.v1beta1.Event", "core.v1.Pod"}
+	for i := 0; i < 10; i++ {
+		definitions := SortDefinitionsByName{
+			{Name: "Pod", Group: "core", Version: "v1", Kind: "Pod"},
+			{Name: "Event", Group: "events", Version: "v1beta1", Kind: "Event"},
+			{Name: "Event", Group: "events", Version: "v1", Kind: "Event"},
+			{Name: "Event", Group: "core", Version: "v1", Kind: "Event"},
+		}
+		// Shuffle the input so the result does not depend on its order
+		definitions.Swap(0, i%len(definitions))
+		sort.Sort(definitions)
+		if got := keys(definitions); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("SortDefinitionsByName = %v, want %v", got, expected)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"flag"
+	"sort"
+)
+
+var PrintSummary = flag.Bool("print-summary", false, "If true, print a summary of the definitions after they are loaded.")
+
+// DefinitionsSummary contains counts describing a set of Definitions
+type DefinitionsSummary struct {
+	Definitions int
+	Toc         int
+	Inlined     int
+	OldVersions int
+	// Groups is the number of distinct api groups
+	Groups int
+	// VersionsByGroup is the number of distinct versions in each group
+	VersionsByGroup map[string]int
+	// UnresolvedRefs is the number of distinct definition names referenced by fields but not found
+	UnresolvedRefs int
+}
+
+// Summary returns counts describing the definitions.  Call after the relationships between definitions are built.
+func (d *Definitions) Summary() DefinitionsSummary {
+	s := DefinitionsSummary{VersionsByGroup: map[string]int{}}
+	versions := map[string]map[string]bool{}
+	for _, definition := range d.GetAllDefinitions() {
+		s.Definitions++
+		if definition.IsInToc() {
+			s.Toc++
+		}
+		if definition.IsInlined {
+			s.Inlined++
+		}
+		if definition.IsOldVersion {
+			s.OldVersions++
+		}
+		g := definition.Group.String()
+		if _, found := versions[g]; !found {
+			versions[g] = map[string]bool{}
+		}
+		versions[g][definition.Version.String()] = true
+	}
+	for g, v := range versions {
+		s.VersionsByGroup[g] = len(v)
+	}
+	s.Groups = len(versions)
+	s.UnresolvedRefs = len(d.UnresolvedRefs())
+	return s
+}
+
+// UnresolvedRefs returns the sorted names of the definitions referenced by the fields in UnresolvedFields
+func (d *Definitions) UnresolvedRefs() []string {
+	found := map[string]bool{}
+	unresolved := []string{}
+	for _, f := range d.unresolvedFields() {
+		if !found[f.name] {
+			found[f.name] = true
+			unresolved = append(unresolved, f.name)
+		}
+	}
+	sort.Strings(unresolved)
+	return unresolved
+}
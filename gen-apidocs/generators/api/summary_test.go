@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"spec":  ref("io.k8s.api.core.v1.PodSpec"),
+			"owner": ref("io.k8s.api.core.v1.Missing"),
+			"port":  ref("io.k8s.apimachinery.pkg.util.intstr.IntOrString"),
+		}),
+		"io.k8s.api.core.v1.PodSpec":         object(schema{"hostname": str()}),
+		"io.k8s.api.apps.v1.Deployment":      object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.ObjectReference": object(schema{"name": str()}),
+	})
+	for _, definition := range d.GetAllDefinitions() {
+		definition.InToc = definition.Name != "ObjectReference"
+	}
+	expected := DefinitionsSummary{
+		Definitions:     5,
+		Toc:             2,
+		Inlined:         1,
+		OldVersions:     1,
+		Groups:          2,
+		VersionsByGroup: map[string]int{"core": 1, "apps": 2},
+		UnresolvedRefs:  1,
+	}
+	if got := d.Summary(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Summary() = %+v, want %+v", got, expected)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteTableOfContents writes a markdown table of contents for the definitions in TocDefinitions
+// with a section for each api group.
+func (d *Definitions) WriteTableOfContents(w io.Writer) error {
+	groups := []string{}
+	byGroup := map[string][]*Definition{}
+	for _, definition := range d.TocDefinitions() {
+		g := definition.GroupDisplayName()
+		if _, found := byGroup[g]; !found {
+			groups = append(groups, g)
+		}
+		byGroup[g] = append(byGroup[g], definition)
+	}
+
+	for i, g := range groups {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "## %s\n\n", g); err != nil {
+			return err
+		}
+		for _, definition := range byGroup[g] {
+			if _, err := fmt.Fprintf(w, "- %s %s\n", definition.MdLink(), definition.Version); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
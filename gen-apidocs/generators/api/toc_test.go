@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTableOfContents(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.Pod":             object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.Service":         object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1.Deployment":      object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1beta1.Deployment": object(schema{"kind": str()}),
+		"io.k8s.api.apps.v1.StatefulSet":     object(schema{"kind": str()}),
+		"io.k8s.api.core.v1.ObjectReference": object(schema{"kind": str()}),
+	})
+	for _, definition := range d.GetAllDefinitions() {
+		definition.InToc = definition.Name != "ObjectReference"
+	}
+
+	tests := []struct {
+		useTags  string
+		expected string
+	}{
+		{"false", `## apps
+
+- [Deployment](#deployment-v1-apps) v1
+- [StatefulSet](#statefulset-v1-apps) v1
+
+## Core
+
+- [Pod](#pod-v1-core) v1
+- [Service](#service-v1-core) v1
+`},
+		{"true", `## apps
+
+- [Deployment](#deployment-v1) v1
+- [StatefulSet](#statefulset-v1) v1
+
+## Core
+
+- [Pod](#pod-v1) v1
+- [Service](#service-v1) v1
+`},
+	}
+	for _, test := range tests {
+		setFlag(t, "use-tags", test.useTags)
+		var b bytes.Buffer
+		if err := d.WriteTableOfContents(&b); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != test.expected {
+			t.Errorf("WriteTableOfContents() with --use-tags=%s =\n%s\nwant\n%s", test.useTags, got, test.expected)
+		}
+	}
+}
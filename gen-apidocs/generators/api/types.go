@@ -26,6 +26,25 @@ type Config struct {
 	OperationCategories []OperationCategory `yaml:"operation_categories,omitempty"`
 	ResourceCategories  []ResourceCategory  `yaml:"resource_categories,omitempty"`
 
+	// DefinitionReplacements are added to the package DefinitionReplacements
+	DefinitionReplacements map[string]string `yaml:"definition_replacements,omitempty"`
+	// DefinitionPrefixes are added to the package DefinitionPrefixes
+	DefinitionPrefixes []string `yaml:"definition_prefixes,omitempty"`
+	// ExperimentalGroups are added to the package ExperimentalGroups
+	ExperimentalGroups []string `yaml:"experimental_groups,omitempty"`
+	// GroupFullNames are added to the package GroupFullNames
+	GroupFullNames map[string]string `yaml:"group_full_names,omitempty"`
+	// NamespacedKinds are added to the package NamespacedKinds
+	NamespacedKinds map[string]bool `yaml:"namespaced_kinds,omitempty"`
+	// DeprecatedRefPrefixes are added to the package DeprecatedRefPrefixes
+	DeprecatedRefPrefixes []string `yaml:"deprecated_ref_prefixes,omitempty"`
+	// PatternHints are added to the package PatternHints
+	PatternHints map[string]string `yaml:"pattern_hints,omitempty"`
+	// ConventionRules are added to the package ConventionRules
+	ConventionRules map[string]bool `yaml:"convention_rules,omitempty"`
+	// TocWeights are added to the package TocWeights
+	TocWeights map[string]int `yaml:"toc_weights,omitempty"`
+
 	Definitions Definitions
 	Operations  Operations
 }
@@ -72,7 +91,7 @@ type Resource struct {
 	Version string `yaml:",omitempty"`
 	Group   string `yaml:",omitempty"`
 	// InlineDefinition is a list of definitions to show along side this resource when displaying it
-	InlineDefinition []string `yaml:inline_definition",omitempty"`
+	InlineDefinition []string `yaml:"inline_definition,omitempty"`
 	// DescriptionWarning is a warning message to show along side this resource when displaying it
 	DescriptionWarning string `yaml:"description_warning,omitempty"`
 	// DescriptionNote is a note message to show along side this resource when displaying it
@@ -17,7 +17,9 @@ limitations under the License.
 package api
 
 import (
+	"flag"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"errors"
@@ -28,31 +30,17 @@ func GetGroupVersionKind() {
 
 }
 
+// DefinitionPrefixes are the definition name prefixes stripped before looking for a leading "api" or "apis"
+// marker. e.g. "io.k8s.api.apps.v1beta1.Deployment".  Prefixes listed under definition_prefixes in config.yaml are added.
+var DefinitionPrefixes = []string{"io.k8s"}
+
 // GetDefinitionVersionKind returns the api version and kind for the spec.  This is the primary key of a Definition.
 func GetDefinitionVersionKind(s spec.Schema) (string, string, string) {
 	// Get the reference for complex types
 	if IsDefinition(s) {
-		s := fmt.Sprintf("%s", s.SchemaProps.Ref.GetPointer())
-		s = strings.Replace(s, "/definitions/", "", -1)
-		name := strings.Split(s, ".")
-
-		var group, version, kind string
-		if name[len(name)-3] == "api" {
-			// e.g. "io.k8s.kubernetes.pkg.api.v1.Pod"
-			group = "core"
-			version = name[len(name)-2]
-			kind = name[len(name)-1]
-		} else if name[len(name)-4] == "apis" {
-			// e.g. "io.k8s.kubernetes.pkg.apis.extensions.v1beta1.Deployment"
-			group = name[len(name)-3]
-			version = name[len(name)-2]
-			kind = name[len(name)-1]
-		} else if name[len(name)-3] == "util" || name[len(name)-3] == "pkg" {
-			// e.g. io.k8s.apimachinery.pkg.util.intstr.IntOrString
-			// e.g. io.k8s.apimachinery.pkg.runtime.RawExtension
-			return "", "", ""
-		} else {
-			panic(errors.New(fmt.Sprintf("Could not locate group for %s", name)))
+		group, version, kind, err := parseDefinitionName(definitionNameForRef(s.SchemaProps.Ref))
+		if err != nil {
+			panic(err)
 		}
 		return group, version, kind
 	}
@@ -63,6 +51,61 @@ func GetDefinitionVersionKind(s spec.Schema) (string, string, string) {
 	return "", "", ""
 }
 
+var ShortDefinitionNames = flag.Bool("short-definition-names", false, "If true, accept definitions named <group>.<version>.<kind> e.g. acme.v1.Thing.")
+
+// shortNameVersion matches the version of short definition names
+var shortNameVersion = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// parseDefinitionName returns the group, version and kind encoded in an open-api definition name.  The kind
+// is empty for names that are not part of an api group.
+func parseDefinitionName(name string) (string, string, string, error) {
+	parts := strings.Split(name, ".")
+	if len(parts) == 3 && *ShortDefinitionNames && shortNameVersion.MatchString(parts[1]) {
+		// e.g. "acme.v1.Thing"
+		return parts[0], parts[1], parts[2], nil
+	}
+	if len(parts) < 4 {
+		return "", "", "", nil
+	}
+	if parts[len(parts)-3] == "api" {
+		// e.g. "io.k8s.kubernetes.pkg.api.v1.Pod"
+		return "core", parts[len(parts)-2], parts[len(parts)-1], nil
+	} else if parts[len(parts)-4] == "apis" {
+		// e.g. "io.k8s.kubernetes.pkg.apis.extensions.v1beta1.Deployment"
+		return parts[len(parts)-3], parts[len(parts)-2], parts[len(parts)-1], nil
+	} else if parts[len(parts)-3] == "util" || parts[len(parts)-3] == "pkg" {
+		// e.g. io.k8s.apimachinery.pkg.util.intstr.IntOrString
+		// e.g. io.k8s.apimachinery.pkg.runtime.RawExtension
+		return "", "", "", nil
+	}
+	for _, prefix := range DefinitionPrefixes {
+		if !strings.HasPrefix(name, prefix+".") {
+			continue
+		}
+		// e.g. "io.k8s.api.core.v1.Pod" or "com.acme.apis.widgets.v1.Widget"
+		rest := strings.Split(strings.TrimPrefix(name, prefix+"."), ".")
+		if len(rest) == 4 && (rest[0] == "api" || rest[0] == "apis") {
+			return rest[1], rest[2], rest[3], nil
+		}
+	}
+	return "", "", "", errors.New(fmt.Sprintf("Could not locate group for %s", name))
+}
+
+// definitionRefPrefixes are the json pointer prefixes of references to definitions in open-api v2 and v3 specs
+var definitionRefPrefixes = []string{"/definitions/", "/components/schemas/"}
+
+// definitionNameForRef returns the definition name referenced by ref
+// e.g. "io.k8s.api.apps.v1.Deployment" for "#/definitions/io.k8s.api.apps.v1.Deployment"
+func definitionNameForRef(ref spec.Ref) string {
+	name := ref.GetPointer().String()
+	for _, prefix := range definitionRefPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix)
+		}
+	}
+	return name
+}
+
 // GetTypeName returns the display name of a Schema.  This is the api kind for definitions and the type for
 // primitive types.  Arrays of objects have "array" appended.
 func GetTypeName(s spec.Schema) string {
@@ -82,6 +125,30 @@ func GetTypeName(s spec.Schema) string {
 	panic(fmt.Errorf("No type found for object %v", s))
 }
 
+// GetFieldKind returns the shape of a schema: a primitive, a complex object, an array or a map
+func GetFieldKind(s spec.Schema) FieldKind {
+	switch {
+	case IsDefinition(s):
+		return FieldKindObject
+	case IsArray(s):
+		return FieldKindArray
+	case IsMap(s):
+		return FieldKindMap
+	case len(s.Type) == 0:
+		return FieldKindUnknown
+	case s.Type[0] == "object":
+		return FieldKindObject
+	case s.Type[0] == "string", s.Type[0] == "integer", s.Type[0] == "number", s.Type[0] == "boolean":
+		return FieldKindScalar
+	}
+	return FieldKindUnknown
+}
+
+// IsMap returns true if the type is an object with only additional properties e.g. map[string]string.
+func IsMap(s spec.Schema) bool {
+	return len(s.Type) > 0 && s.Type[0] == "object" && s.AdditionalProperties != nil && len(s.Properties) == 0
+}
+
 // IsArray returns true if the type is an array type.
 func IsArray(s spec.Schema) bool {
 	//if s == nil {
@@ -94,3 +161,120 @@ func IsArray(s spec.Schema) bool {
 func IsDefinition(s spec.Schema) bool {
 	return len(s.SchemaProps.Ref.GetPointer().String()) > 0
 }
+
+// isRequired returns true if the property is listed as required by the schema
+func isRequired(s spec.Schema, property string) bool {
+	for _, r := range s.Required {
+		if r == property {
+			return true
+		}
+	}
+	return false
+}
+
+// isImmutable returns true if the schema is marked immutable by the ImmutableKey extension or by a
+// "self == oldSelf" validation rule.
+func isImmutable(s spec.Schema) bool {
+	if immutable, found := s.Extensions.GetBool(*ImmutableKey); found && immutable {
+		return true
+	}
+	rules, _ := s.Extensions[validationsKey].([]interface{})
+	for _, r := range rules {
+		rule, _ := r.(map[string]interface{})
+		if expr, ok := rule["rule"].(string); ok && strings.Replace(expr, " ", "", -1) == "self==oldSelf" {
+			return true
+		}
+	}
+	return false
+}
+
+// isHiddenField returns true if the named property should be left out of the docs.  With --hide-status this is
+// the "status" property of each definition, so the fields rooted at status are not shown.
+func isHiddenField(name string) bool {
+	return *HideStatus && name == "status"
+}
+
+// referencedName returns the name of the definition referenced by s or its items or values
+func referencedName(s spec.Schema) string {
+	switch {
+	case IsDefinition(s):
+		return definitionNameForRef(s.Ref)
+	case IsArray(s) && s.Items != nil && s.Items.Schema != nil:
+		return referencedName(*s.Items.Schema)
+	case IsMap(s) && s.AdditionalProperties.Schema != nil:
+		return referencedName(*s.AdditionalProperties.Schema)
+	}
+	return ""
+}
+
+// hasAnyPrefix returns true if s starts with one of prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// enumValues returns the allowed values of a schema formatted as strings
+func enumValues(s spec.Schema) []string {
+	values := []string{}
+	for _, v := range s.Enum {
+		values = append(values, fmt.Sprintf("%v", v))
+	}
+	return values
+}
+
+// enumDescriptions returns the descriptions of the enum values of s keyed by value.  The extension may either be
+// a list aligned with values, in which case values without a description are skipped, or a map from value to
+// description.
+func enumDescriptions(s spec.Schema, values []string) map[string]string {
+	descriptions := map[string]string{}
+	switch ext := s.Extensions[strings.ToLower(*EnumDescriptionsKey)].(type) {
+	case []interface{}:
+		for i, d := range ext {
+			if i >= len(values) {
+				break
+			}
+			if d, ok := d.(string); ok && len(d) > 0 {
+				descriptions[values[i]] = d
+			}
+		}
+	case map[string]interface{}:
+		for v, d := range ext {
+			if d, ok := d.(string); ok && len(d) > 0 {
+				descriptions[v] = d
+			}
+		}
+	}
+	return descriptions
+}
+
+// abbreviations end in a period without ending a sentence
+var abbreviations = map[string]bool{"e.g.": true, "i.e.": true, "etc.": true, "vs.": true, "approx.": true}
+
+// shortDescription returns the first sentence of description.  If the sentence is longer than maxLen
+// characters it is cut at a word boundary and ends with an ellipsis.  maxLen <= 0 does not limit the length.
+func shortDescription(description string, maxLen int) string {
+	words := strings.Fields(description)
+	sentence := []string{}
+	for _, word := range words {
+		sentence = append(sentence, word)
+		if strings.HasSuffix(word, ".") && !abbreviations[strings.ToLower(strings.TrimLeft(word, "(\""))] {
+			break
+		}
+	}
+	short := strings.Join(sentence, " ")
+	if maxLen <= 0 || len([]rune(short)) <= maxLen {
+		return short
+	}
+	runes := []rune(short)
+	cut := string(runes[:maxLen-1])
+	if runes[maxLen-1] != ' ' {
+		if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, " ,;:") + "…"
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func TestGetFieldKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *spec.Schema
+		expected FieldKind
+	}{
+		{"string", spec.StringProperty(), FieldKindScalar},
+		{"integer", spec.Int64Property(), FieldKindScalar},
+		{"boolean", spec.BoolProperty(), FieldKindScalar},
+		{"reference", spec.RefSchema("#/definitions/io.k8s.api.core.v1.PodSpec"), FieldKindObject},
+		{"inline object", &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}, FieldKindObject},
+		{"array", spec.ArrayProperty(spec.StringProperty()), FieldKindArray},
+		{"map", spec.MapProperty(spec.StringProperty()), FieldKindMap},
+		{"untyped", &spec.Schema{}, FieldKindUnknown},
+	}
+	for _, test := range tests {
+		if got := GetFieldKind(*test.schema); got != test.expected {
+			t.Errorf("%s GetFieldKind() = %v, want %v", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestHideStatus(t *testing.T) {
+	definitions := schema{
+		"io.k8s.api.core.v1.Pod": object(schema{
+			"kind":   str(),
+			"spec":   ref("io.k8s.api.core.v1.PodSpec"),
+			"status": ref("io.k8s.api.core.v1.PodStatus"),
+		}),
+		"io.k8s.api.core.v1.PodSpec":      object(schema{"hostname": str()}),
+		"io.k8s.api.core.v1.PodStatus":    object(schema{"conditions": schema{"type": "array", "items": ref("io.k8s.api.core.v1.PodCondition")}}),
+		"io.k8s.api.core.v1.PodCondition": object(schema{"status": str(), "type": str()}),
+		"io.k8s.api.apps.v1.Scale": object(schema{
+			"kind":   str(),
+			"status": ref("io.k8s.api.apps.v1.ScaleObserved"),
+		}),
+		"io.k8s.api.apps.v1.ScaleObserved": object(schema{"replicas": schema{"type": "integer"}}),
+	}
+	tests := []struct {
+		hide         string
+		pod          []string
+		condition    []string
+		scale        []string
+		appearsIn    int
+		statusFields []string
+	}{
+		{"false", []string{"kind", "spec", "status"}, []string{"status", "type"}, []string{"kind", "status"}, 1, []string{"conditions"}},
+		{"true", []string{"kind", "spec"}, []string{"type"}, []string{"kind"}, 0, []string{}},
+	}
+	for _, test := range tests {
+		setFlag(t, "hide-status", test.hide)
+		d := newDefinitions(t, definitions)
+		pod := mustGet(t, &d, "core.v1.Pod")
+		if got := fieldNames(pod.Fields); !reflect.DeepEqual(got, test.pod) {
+			t.Errorf("--hide-status=%s Pod fields = %v, want %v", test.hide, got, test.pod)
+		}
+		if got := fieldNames(pod.StatusFields()); !reflect.DeepEqual(got, test.statusFields) {
+			t.Errorf("--hide-status=%s Pod StatusFields() = %v, want %v", test.hide, got, test.statusFields)
+		}
+		if got := fieldNames(mustGet(t, &d, "core.v1.PodCondition").Fields); !reflect.DeepEqual(got, test.condition) {
+			t.Errorf("--hide-status=%s PodCondition fields = %v, want %v", test.hide, got, test.condition)
+		}
+		if got := fieldNames(mustGet(t, &d, "apps.v1.Scale").Fields); !reflect.DeepEqual(got, test.scale) {
+			t.Errorf("--hide-status=%s Scale fields = %v, want %v", test.hide, got, test.scale)
+		}
+		if got := len(mustGet(t, &d, "core.v1.PodStatus").AppearsIn); got != test.appearsIn {
+			t.Errorf("--hide-status=%s PodStatus AppearsIn = %d definitions, want %d", test.hide, got, test.appearsIn)
+		}
+	}
+}
+
+func TestEnumDescriptions(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.core.v1.PodSpec": object(schema{
+			"restartPolicy": schema{
+				"type":                           "string",
+				"enum":                           []string{"Always", "OnFailure", "Never"},
+				"x-kubernetes-enum-descriptions": []string{"Always restart.", "Restart on failure.", "Never restart."},
+			},
+			"dnsPolicy": schema{
+				"type":                           "string",
+				"enum":                           []string{"ClusterFirst", "Default", "None"},
+				"x-kubernetes-enum-descriptions": []string{"Use the cluster DNS.", "Use the node DNS.", "Ignored.", "Extra."},
+			},
+			"preemptionPolicy": schema{
+				"type":                           "string",
+				"enum":                           []string{"Never", "PreemptLowerPriority"},
+				"x-kubernetes-enum-descriptions": []string{"Never preempt."},
+			},
+			"phase": schema{
+				"type":                           "string",
+				"enum":                           []string{"Pending", "Running"},
+				"x-kubernetes-enum-descriptions": schema{"Running": "Bound to a node."},
+			},
+		}),
+	})
+	tests := map[string]map[string]string{
+		"restartPolicy":    {"Always": "Always restart.", "OnFailure": "Restart on failure.", "Never": "Never restart."},
+		"dnsPolicy":        {"ClusterFirst": "Use the cluster DNS.", "Default": "Use the node DNS.", "None": "Ignored."},
+		"preemptionPolicy": {"Never": "Never preempt."},
+		"phase":            {"Running": "Bound to a node."},
+	}
+	definition := mustGet(t, &d, "core.v1.PodSpec")
+	for name, expected := range tests {
+		if field, _ := definition.GetField(name); !reflect.DeepEqual(field.EnumDescriptions, expected) {
+			t.Errorf("%s EnumDescriptions = %v, want %v", name, field.EnumDescriptions, expected)
+		}
+	}
+}
+
+func TestShortDescription(t *testing.T) {
+	tests := []struct {
+		description string
+		maxLen      int
+		expected    string
+	}{
+		{"Pod is a collection of containers. It is created by clients.", 0, "Pod is a collection of containers."},
+		{"A label selector (e.g. app=web) matching pods. More info below.", 0, "A label selector (e.g. app=web) matching pods."},
+		{"Values i.e. strings, numbers, etc. are allowed. Others are not.", 0, "Values i.e. strings, numbers, etc. are allowed."},
+		{"No trailing period", 0, "No trailing period"},
+		{"Pod is a collection of containers that can run on a host.", 20, "Pod is a collection…"},
+		{"Pod is a collection of containers.", 100, "Pod is a collection of containers."},
+		{"", 10, ""},
+	}
+	for _, test := range tests {
+		if got := shortDescription(test.description, test.maxLen); got != test.expected {
+			t.Errorf("shortDescription(%q, %d) = %q, want %q", test.description, test.maxLen, got, test.expected)
+		}
+	}
+}
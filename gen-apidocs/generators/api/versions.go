@@ -0,0 +1,159 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldVersionInfo describes a field in one version of a kind
+type FieldVersionInfo struct {
+	Definition *Definition
+	// Present is false if the version does not have the field
+	Present  bool
+	Type     string
+	Required bool
+}
+
+// FieldEvolution returns the field's type and required flag in each version of kind, from oldest to newest
+func (d *Definitions) FieldEvolution(kind, fieldName string) []FieldVersionInfo {
+	infos := []FieldVersionInfo{}
+	versions := d.ByKind[kind]
+	for i := len(versions) - 1; i >= 0; i-- {
+		info := FieldVersionInfo{Definition: versions[i]}
+		if field, found := versions[i].GetField(fieldName); found {
+			info.Present = true
+			info.Type = field.Type
+			info.Required = field.Required
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// IdenticalFieldDescriptions returns the fields present in every version of kind with the same description
+// in each, mapped to that description.  Whitespace is normalized before comparing descriptions.
+func (d *Definitions) IdenticalFieldDescriptions(kind string) map[string]string {
+	identical := map[string]string{}
+	versions := d.ByKind[kind]
+	if len(versions) == 0 {
+		return identical
+	}
+	for _, field := range versions[0].Fields {
+		description := normalizeWhitespace(field.Description)
+		same := true
+		for _, other := range versions[1:] {
+			f, found := other.GetField(field.Name)
+			if !found || normalizeWhitespace(f.Description) != description {
+				same = false
+				break
+			}
+		}
+		if same {
+			identical[field.Name] = description
+		}
+	}
+	return identical
+}
+
+// normalizeWhitespace collapses runs of whitespace into a single space and trims the ends
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// FieldStability describes whether a field is the same in every version of a kind
+type FieldStability struct {
+	// Versions is the number of versions of the kind
+	Versions int
+	// PresentInAll is true if every version has the field
+	PresentInAll bool
+	// SameType is true if every version has the field with the same type
+	SameType bool
+	// Type is the type of the field when SameType is true
+	Type string
+}
+
+// FieldStabilityAcrossVersions returns whether the field is present with the same type in every version of kind
+func (d *Definitions) FieldStabilityAcrossVersions(kind, fieldName string) FieldStability {
+	infos := d.FieldEvolution(kind, fieldName)
+	s := FieldStability{Versions: len(infos), PresentInAll: len(infos) > 0, SameType: len(infos) > 0}
+	for i, info := range infos {
+		if !info.Present {
+			s.PresentInAll = false
+			s.SameType = false
+			continue
+		}
+		if i > 0 && info.Type != infos[0].Type {
+			s.SameType = false
+		}
+	}
+	if s.SameType {
+		s.Type = infos[0].Type
+	}
+	return s
+}
+
+// UnifiedTable is a table of the fields of every version of a kind with a column for each version
+type UnifiedTable struct {
+	// Versions are the columns of the table, newest first
+	Versions []*Definition
+	// Rows has a row for each field found in any version, ordered by field name
+	Rows []UnifiedRow
+}
+
+// UnifiedRow is a field of a UnifiedTable with a cell for each version
+type UnifiedRow struct {
+	Name  string
+	Cells []UnifiedCell
+}
+
+// UnifiedCell is a field in one version of a kind
+type UnifiedCell struct {
+	// Present is false if the version does not have the field
+	Present bool
+	Type    string
+}
+
+// UnifiedFieldTable returns a table of the fields of kind with the type of each field in each version
+func (d *Definitions) UnifiedFieldTable(kind string) UnifiedTable {
+	table := UnifiedTable{Versions: d.ByKind[kind]}
+	names := []string{}
+	seen := map[string]bool{}
+	for _, version := range table.Versions {
+		for _, field := range version.Fields {
+			if !seen[field.Name] {
+				seen[field.Name] = true
+				names = append(names, field.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		row := UnifiedRow{Name: name}
+		for _, version := range table.Versions {
+			cell := UnifiedCell{}
+			if field, found := version.GetField(name); found {
+				cell.Present = true
+				cell.Type = field.Type
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
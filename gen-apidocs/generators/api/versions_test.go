@@ -0,0 +1,150 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newCronJobDefinitions returns three versions of CronJob where the schedule field changes type and the
+// suspend field is added in v1
+func newCronJobDefinitions(t *testing.T) Definitions {
+	return newDefinitions(t, schema{
+		"io.k8s.api.batch.v2alpha1.CronJob": schema{
+			"type":       "object",
+			"properties": schema{"schedule": schema{"type": "integer"}},
+		},
+		"io.k8s.api.batch.v1beta1.CronJob": schema{
+			"type":       "object",
+			"properties": schema{"schedule": describedStr("The schedule in Cron format.")},
+		},
+		"io.k8s.api.batch.v1.CronJob": schema{
+			"type":     "object",
+			"required": []string{"schedule"},
+			"properties": schema{
+				"schedule": describedStr("The schedule in Cron format."),
+				"suspend":  schema{"type": "boolean"},
+			},
+		},
+	})
+}
+
+func TestFieldEvolution(t *testing.T) {
+	d := newCronJobDefinitions(t)
+	type version struct {
+		Version  ApiVersion
+		Present  bool
+		Type     string
+		Required bool
+	}
+	got := []version{}
+	for _, info := range d.FieldEvolution("CronJob", "schedule") {
+		got = append(got, version{info.Definition.Version, info.Present, info.Type, info.Required})
+	}
+	expected := []version{
+		{"v2alpha1", true, "integer", false},
+		{"v1beta1", true, "string", false},
+		{"v1", true, "string", true},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("FieldEvolution(schedule) = %+v, want %+v", got, expected)
+	}
+
+	present := []bool{}
+	for _, info := range d.FieldEvolution("CronJob", "suspend") {
+		present = append(present, info.Present)
+	}
+	if expected := []bool{false, false, true}; !reflect.DeepEqual(present, expected) {
+		t.Errorf("FieldEvolution(suspend) present = %v, want %v", present, expected)
+	}
+}
+
+func TestIdenticalFieldDescriptions(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.batch.v1beta1.CronJob": object(schema{
+			"schedule": describedStr("The schedule in\nCron format."),
+			"suspend":  describedStr("Suspend subsequent executions."),
+			"jobs":     describedStr("Jobs to keep."),
+		}),
+		"io.k8s.api.batch.v1.CronJob": object(schema{
+			"schedule": describedStr(" The schedule in  Cron format. "),
+			"suspend":  describedStr("This flag tells the controller to suspend subsequent executions."),
+		}),
+	})
+	expected := map[string]string{"schedule": "The schedule in Cron format."}
+	if got := d.IdenticalFieldDescriptions("CronJob"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("IdenticalFieldDescriptions() = %v, want %v", got, expected)
+	}
+	if got := d.IdenticalFieldDescriptions("Missing"); len(got) != 0 {
+		t.Errorf("IdenticalFieldDescriptions() of a missing kind = %v, want empty", got)
+	}
+}
+
+func TestFieldStabilityAcrossVersions(t *testing.T) {
+	d := newCronJobDefinitions(t)
+	tests := map[string]FieldStability{
+		"schedule": {Versions: 3, PresentInAll: true, SameType: false},
+		"suspend":  {Versions: 3, PresentInAll: false, SameType: false},
+	}
+	for name, expected := range tests {
+		if got := d.FieldStabilityAcrossVersions("CronJob", name); got != expected {
+			t.Errorf("FieldStabilityAcrossVersions(%s) = %+v, want %+v", name, got, expected)
+		}
+	}
+
+	d = newDefinitions(t, schema{
+		"io.k8s.api.batch.v1beta1.CronJob": object(schema{"schedule": str()}),
+		"io.k8s.api.batch.v1.CronJob":      object(schema{"schedule": str()}),
+	})
+	expected := FieldStability{Versions: 2, PresentInAll: true, SameType: true, Type: "string"}
+	if got := d.FieldStabilityAcrossVersions("CronJob", "schedule"); got != expected {
+		t.Errorf("FieldStabilityAcrossVersions() of a stable field = %+v, want %+v", got, expected)
+	}
+	if got := d.FieldStabilityAcrossVersions("Missing", "schedule"); got != (FieldStability{}) {
+		t.Errorf("FieldStabilityAcrossVersions() of a missing kind = %+v, want zero", got)
+	}
+}
+
+func TestUnifiedFieldTable(t *testing.T) {
+	d := newDefinitions(t, schema{
+		"io.k8s.api.batch.v1beta1.CronJob": object(schema{
+			"schedule":      schema{"type": "integer"},
+			"startDeadline": schema{"type": "integer"},
+		}),
+		"io.k8s.api.batch.v1.CronJob": object(schema{
+			"schedule": str(),
+			"suspend":  schema{"type": "boolean"},
+		}),
+	})
+	table := d.UnifiedFieldTable("CronJob")
+	versions := []ApiVersion{}
+	for _, v := range table.Versions {
+		versions = append(versions, v.Version)
+	}
+	if expected := []ApiVersion{"v1", "v1beta1"}; !reflect.DeepEqual(versions, expected) {
+		t.Errorf("Versions = %v, want %v", versions, expected)
+	}
+	expected := []UnifiedRow{
+		{Name: "schedule", Cells: []UnifiedCell{{true, "string"}, {true, "integer"}}},
+		{Name: "startDeadline", Cells: []UnifiedCell{{false, ""}, {true, "integer"}}},
+		{Name: "suspend", Cells: []UnifiedCell{{true, "boolean"}, {false, ""}}},
+	}
+	if !reflect.DeepEqual(table.Rows, expected) {
+		t.Errorf("Rows = %+v, want %+v", table.Rows, expected)
+	}
+}
@@ -135,26 +135,16 @@ func WriteIndexFile(config *api.Config) {
 	}
 
 	// Add other definition imports
-	definitions := api.SortDefinitionsByName{}
-	for _, definition := range config.Definitions.GetAllDefinitions() {
-
-		// Don't add definitions for top level resources in the toc or inlined resources
-		if definition.InToc || definition.IsInlined || definition.IsOldVersion {
-			continue
-		}
-		definitions = append(definitions, definition)
-	}
-	sort.Sort(definitions)
 	manifest.Docs = append(manifest.Docs, Doc{"_definitions.md"})
 	includes = append(includes, "definitions")
-	for _, d := range definitions {
+	for _, d := range config.Definitions.StandaloneDefinitions() {
 		//definitions[i] = GetDefinitionImport(name)
 		manifest.Docs = append(manifest.Docs, Doc{"_" + GetDefinitionImport(d) + ".md"})
 		includes = append(includes, GetDefinitionImport(d))
 	}
 
 	// Add definitions for older version of objects
-	definitions = api.SortDefinitionsByName{}
+	definitions := api.SortDefinitionsByName{}
 	for _, definition := range config.Definitions.GetAllDefinitions() {
 		// Don't add definitions for top level resources in the toc or inlined resources
 		if definition.IsOldVersion {
@@ -230,11 +220,8 @@ func WriteDefinitionFiles(config *api.Config) {
 		os.Exit(1)
 	}
 
-	for _, definition := range config.Definitions.GetAllDefinitions() {
-		// Skip things already present in concept docs
-		if definition.InToc || definition.IsInlined || definition.IsOldVersion {
-			continue
-		}
+	// Skip things already present in concept docs
+	for _, definition := range config.Definitions.StandaloneDefinitions() {
 		WriteTemplate(t, definition, GetDefinitionFilePath(definition))
 	}
 }
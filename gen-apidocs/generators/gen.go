@@ -16,11 +16,20 @@ limitations under the License.
 
 package generators
 
-import "github.com/kubernetes-incubator/reference-docs/gen-apidocs/generators/api"
+import (
+	"fmt"
+	"os"
+
+	"github.com/kubernetes-incubator/reference-docs/gen-apidocs/generators/api"
+)
 
 func GenerateFiles() {
 	// Load the yaml config
-	config := api.NewConfig()
+	config, err := api.NewConfig()
+	if err != nil {
+		os.Stderr.WriteString(fmt.Sprintf("%v\n", err))
+		os.Exit(1)
+	}
 
 	PrintInfo(config)
 	WriteTemplates(config)
@@ -18,21 +18,25 @@ package generators
 
 var DefinitionTemplate = `
 {{define "definition.template"}}## {{.Name}} {{.Version}} {{.Group}}
+{{- if .AnchorPrefix}}
+<a id="{{.Anchor}}"></a>{{end}}
 
 Group        | Version     | Kind
 ------------ | ---------- | -----------
 {{.GroupDisplayName}} | {{.Version}} | {{.Name}}
 
 {{if .OtherVersions}}<aside class="notice">Other api versions of this object exist: {{range $v := .OtherVersions}}{{$v.VersionLink}} {{end}}</aside>{{end}}
+{{- if .ReplacedBy}}
+<aside class="warning">This version is deprecated.  Use {{.ReplacedBy.HrefLink}} {{.ReplacedBy.Version}} {{.ReplacedBy.Group}} instead.</aside>{{end}}
 
 {{.Description}}
 
 {{if .AppearsIn}}<aside class="notice">
-Appears In {{range $appearsin := .AppearsIn}} {{$appearsin.HrefLink}} {{end}}</aside>{{end}}
+Appears In {{range $appearsin := .AppearsIn}} {{$appearsin.HrefLink}} {{end}}</aside>{{end}}{{if .AllowsAdditional}}<aside class="notice">Properties other than the fields below are allowed with values of type *{{.AdditionalType}}*.</aside>{{end}}
 
 Field        | Description
 ------------ | -----------
-{{range $field := .Fields}}{{$field.Name}} {{if $field.Link}}<br /> *{{$field.Link}}* {{end}} {{if $field.PatchStrategy}}<br /> **patch type**: *{{$field.PatchStrategy}}* {{end}} {{if $field.PatchMergeKey}}<br /> **patch merge key**: *{{$field.PatchMergeKey}}* {{end}} | {{$field.Description}}
+{{range $field := .Fields}}{{with $.FieldAnchor $field}}<a id="{{.}}"></a>{{end}}{{$field.Name}} {{if $field.Link}}<br /> *{{$field.Link}}* {{end}} {{if $field.PatchStrategy}}<br /> **patch type**: *{{$field.PatchStrategy}}* {{end}} {{if $field.PatchMergeKey}}<br /> **patch merge key**: *{{$field.PatchMergeKey}}* {{end}}{{if $field.Bounds}}<br /> **bounds**: *{{$field.Bounds}}* {{end}}{{if $field.Pattern}}<br /> **pattern**: *{{$field.PatternHint}}* {{end}}{{if $field.Enum}}<br /> **allowed values**: {{range $v := $field.Enum}}<br /> <code>{{$v}}</code>{{with index $field.EnumDescriptions $v}}: {{.}}{{end}}{{end}} {{end}}{{if $field.Experimental}}<br /> **experimental** {{end}}{{if $field.Immutable}}<br /> **immutable** {{end}} | {{$field.Description}}
 {{end}}
 {{end}}
 `
@@ -70,8 +74,10 @@ Code         | Description
 
 -----------
 # {{.Name}} {{.Definition.Version}} {{if .Definition.ShowGroup}}{{.Definition.Group}}{{end}}
+{{- if .Definition.AnchorPrefix}}
+<a id="{{.Definition.Anchor}}"></a>{{end}}
 
-{{if .Definition.Sample.Sample}}{{$n := .Definition.Sample.Note}}{{range $e := .Definition.GetSamples}}>{{$e.Tab}} {{$n}}
+{{if .Definition.HasSamples}}{{$n := .Definition.Sample.Note}}{{range $e := .Definition.GetSamples}}>{{$e.Tab}} {{$n}}
 
 ` + "```" + `{{$e.Type}}
 
@@ -83,31 +89,38 @@ Code         | Description
 Group        | Version     | Kind
 ------------ | ---------- | -----------
 {{.Definition.GroupDisplayName}} | {{.Definition.Version}} | {{.Name}}
+{{- with .Definition.NamespacedDisplay}}
+
+Namespaced: {{.}}{{end}}
 
 {{if .DescriptionWarning}}<aside class="warning">{{.DescriptionWarning}}</aside>{{end}}
 {{if .DescriptionNote}}<aside class="notice">{{.DescriptionNote}}</aside>{{end}}
 
 {{if .Definition.OtherVersions}}<aside class="notice">Other api versions of this object exist: {{range $v := .Definition.OtherVersions}}{{$v.VersionLink}} {{end}}</aside>{{end}}
+{{- if .Definition.ReplacedBy}}
+<aside class="warning">This version is deprecated.  Use {{.Definition.ReplacedBy.HrefLink}} {{.Definition.ReplacedBy.Version}} {{.Definition.ReplacedBy.Group}} instead.</aside>{{end}}
 
 
 {{.Definition.Description}}
 
 {{if .Definition.AppearsIn}}<aside class="notice">
-Appears In {{range $appearsin := .Definition.AppearsIn}}{{$appearsin.HrefLink}} {{end}}</aside>{{end}}
+Appears In {{range $appearsin := .Definition.AppearsIn}}{{$appearsin.HrefLink}} {{end}}</aside>{{end}}{{if .Definition.AllowsAdditional}}<aside class="notice">Properties other than the fields below are allowed with values of type *{{.Definition.AdditionalType}}*.</aside>{{end}}
 
 Field        | Description
 ------------ | -----------
-{{range $field := .Definition.Fields}}{{$field.Name}} {{if $field.Link}}<br /> *{{$field.Link}}* {{end}} {{if $field.PatchStrategy}}<br /> **patch type**: *{{$field.PatchStrategy}}* {{end}} {{if $field.PatchMergeKey}}<br /> **patch merge key**: *{{$field.PatchMergeKey}}* {{end}} | {{$field.Description}}
+{{range $field := .Definition.Fields}}{{with $.Definition.FieldAnchor $field}}<a id="{{.}}"></a>{{end}}{{$field.Name}} {{if $field.Link}}<br /> *{{$field.Link}}* {{end}} {{if $field.PatchStrategy}}<br /> **patch type**: *{{$field.PatchStrategy}}* {{end}} {{if $field.PatchMergeKey}}<br /> **patch merge key**: *{{$field.PatchMergeKey}}* {{end}}{{if $field.Bounds}}<br /> **bounds**: *{{$field.Bounds}}* {{end}}{{if $field.Pattern}}<br /> **pattern**: *{{$field.PatternHint}}* {{end}}{{if $field.Enum}}<br /> **allowed values**: {{range $v := $field.Enum}}<br /> <code>{{$v}}</code>{{with index $field.EnumDescriptions $v}}: {{.}}{{end}}{{end}} {{end}}{{if $field.Experimental}}<br /> **experimental** {{end}}{{if $field.Immutable}}<br /> **immutable** {{end}} | {{$field.Description}}
 {{end}}
 
 {{if .Definition.Inline}}{{range $inline := .Definition.Inline}}### {{$inline.Name}} {{$inline.Version}} {{$inline.Group}}
+{{- if $inline.AnchorPrefix}}
+<a id="{{$inline.Anchor}}"></a>{{end}}
 
 {{if $inline.AppearsIn}}<aside class="notice">
 Appears In {{range $appearsin := $inline.AppearsIn}}{{$appearsin.HrefLink}} {{end}}</aside>{{end}}
 
 Field        | Description
 ------------ | -----------
-{{range $field := $inline.Fields}}{{$field.Name}} {{if $field.Link}}<br /> *{{$field.Link}}* {{end}} {{if $field.PatchStrategy}}<br /> **patch type**: *{{$field.PatchStrategy}}* {{end}} {{if $field.PatchMergeKey}}<br /> **patch merge key**: *{{$field.PatchMergeKey}}* {{end}} | {{$field.Description}}
+{{range $field := $inline.Fields}}{{with $.Definition.FieldAnchor $field}}<a id="{{.}}"></a>{{end}}{{$field.Name}} {{if $field.Link}}<br /> *{{$field.Link}}* {{end}} {{if $field.PatchStrategy}}<br /> **patch type**: *{{$field.PatchStrategy}}* {{end}} {{if $field.PatchMergeKey}}<br /> **patch merge key**: *{{$field.PatchMergeKey}}* {{end}}{{if $field.Bounds}}<br /> **bounds**: *{{$field.Bounds}}* {{end}}{{if $field.Pattern}}<br /> **pattern**: *{{$field.PatternHint}}* {{end}}{{if $field.Enum}}<br /> **allowed values**: {{range $v := $field.Enum}}<br /> <code>{{$v}}</code>{{with index $field.EnumDescriptions $v}}: {{.}}{{end}}{{end}} {{end}}{{if $field.Experimental}}<br /> **experimental** {{end}}{{if $field.Immutable}}<br /> **immutable** {{end}} | {{$field.Description}}
 {{end}}
 {{end}}{{end}}
 
@@ -19,6 +19,7 @@ package generators
 import (
 	"fmt"
 	"github.com/kubernetes-incubator/reference-docs/gen-apidocs/generators/api"
+	"sort"
 	"strings"
 )
 
@@ -52,7 +53,7 @@ func PrintInfo(config *api.Config) {
 
 	missingFromToc := false
 	for _, d := range definitions.GetAllDefinitions() {
-		if !d.InToc && len(d.OperationCategories) > 0 && !d.IsOldVersion && !d.IsInlined {
+		if d.IsStandalone() && len(d.OperationCategories) > 0 {
 			missingFromToc = true
 		}
 	}
@@ -61,7 +62,7 @@ func PrintInfo(config *api.Config) {
 		fmt.Printf("----------------------------------\n")
 		fmt.Printf("Definitions with Operations Missing from Toc (Excluding old version):\n")
 		for name, d := range definitions.GetAllDefinitions() {
-			if !d.InToc && len(d.OperationCategories) > 0 && !d.IsOldVersion && !d.IsInlined {
+			if d.IsStandalone() && len(d.OperationCategories) > 0 {
 				fmt.Printf("[%s]\n", name)
 				for _, oc := range d.OperationCategories {
 					for _, o := range oc.Operations {
@@ -73,6 +74,10 @@ func PrintInfo(config *api.Config) {
 		panic("Definitions with operations missing from the ToC")
 	}
 
+	if *api.PrintSummary {
+		PrintSummary(definitions.Summary())
+	}
+
 	//fmt.Printf("Old definitions:\n")
 	//for name, d := range definitions.GetAllDefinitions() {
 	//	if !d.InToc && len(d.OperationCategories) > 0 && d.IsOldVersion && !d.IsInlined {
@@ -86,6 +91,21 @@ func PrintInfo(config *api.Config) {
 	//}
 }
 
+func PrintSummary(s api.DefinitionsSummary) {
+	fmt.Printf("----------------------------------\n")
+	fmt.Printf("Definitions: %d (toc: %d, inlined: %d, old versions: %d)\n", s.Definitions, s.Toc, s.Inlined, s.OldVersions)
+	fmt.Printf("Unresolved references: %d\n", s.UnresolvedRefs)
+	fmt.Printf("Groups: %d\n", s.Groups)
+	groups := []string{}
+	for g := range s.VersionsByGroup {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+	for _, g := range groups {
+		fmt.Printf("\t[%s] versions: %d\n", g, s.VersionsByGroup[g])
+	}
+}
+
 func PrintDebug(config *api.Config) {
 	operations := config.Operations
 	definitions := config.Definitions